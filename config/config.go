@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -10,10 +12,21 @@ import (
 var config *Config
 
 type Config struct {
-	Port               string
-	MongoURI           string
-	Database           string
-	BasePlatformAPIUrl string
+	Port                    string
+	MongoURI                string
+	Database                string
+	BasePlatformAPIUrl      string
+	BotSeatWaitSeconds      int    // Seconds a WAITING board waits for humans before bots fill the empty seats, 0 disables bots
+	BotTicketAmounts        []int  // Ticket amounts of the tiers bots may be seated on, none by default
+	BotDifficulty           string // Difficulty of the seated bots (EASY, MEDIUM, HARD)
+	RuleSet                 string // Name of the house rules preset new boards are played with
+	PawnsPerQuadrant        int    // Pawns every quadrant of the new boards plays with, 1 to 4
+	StartOrder              string // How the first turn of new boards is picked (DICE_OFF, RANDOM, FIXED)
+	AdminApiKey             string // Key of the admin endpoints, they are disabled without one
+	SlowConsumer            string // What happens to messages for a connection with a full queue (DROP, COALESCE, DISCONNECT)
+	SendQueueSize           int    // Messages a connection queues before it is a slow consumer
+	DuplicateSession        string // What happens when a player opens a board it is connected to again (REPLACE, REJECT)
+	ShutdownDeadlineSeconds int    // Seconds the server has to drain on SIGTERM before it exits
 }
 
 func GetConfig() Config {
@@ -30,10 +43,21 @@ func LoadConfig() {
 	}
 
 	config = &Config{
-		Port:               getEnv("PORT", "8080"),
-		MongoURI:           getEnv("MONGO_URI", "mongodb://localhost:27017"),
-		Database:           getEnv("DATABASE", "gameserver"),
-		BasePlatformAPIUrl: getEnv("BASE_PLATFORM_API_URL", "http://localhost:4000"),
+		Port:                    getEnv("PORT", "8080"),
+		MongoURI:                getEnv("MONGO_URI", "mongodb://localhost:27017"),
+		Database:                getEnv("DATABASE", "gameserver"),
+		BasePlatformAPIUrl:      getEnv("BASE_PLATFORM_API_URL", "http://localhost:4000"),
+		BotSeatWaitSeconds:      getEnvAsInt("BOT_SEAT_WAIT_SECONDS", 0),
+		BotTicketAmounts:        getEnvAsInts("BOT_TICKET_AMOUNTS"),
+		BotDifficulty:           getEnv("BOT_DIFFICULTY", "MEDIUM"),
+		RuleSet:                 getEnv("RULE_SET", "CLASSIC"),
+		PawnsPerQuadrant:        getEnvAsInt("PAWNS_PER_QUADRANT", 4),
+		StartOrder:              getEnv("START_ORDER", "DICE_OFF"),
+		AdminApiKey:             getEnv("ADMIN_API_KEY", ""),
+		SlowConsumer:            getEnv("SLOW_CONSUMER_POLICY", "DISCONNECT"),
+		SendQueueSize:           getEnvAsInt("SEND_QUEUE_SIZE", 256),
+		DuplicateSession:        getEnv("DUPLICATE_SESSION_POLICY", "REPLACE"),
+		ShutdownDeadlineSeconds: getEnvAsInt("SHUTDOWN_DEADLINE_SECONDS", 30),
 	}
 }
 
@@ -43,3 +67,37 @@ func getEnv(key string, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvAsInt(key string, defaultValue int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid value %s for %s, using default %d", value, key, defaultValue)
+		return defaultValue
+	}
+	return intValue
+}
+
+// getEnvAsInts reads a comma separated list of integers, the invalid values are left out
+func getEnvAsInts(key string) []int {
+	values := []int{}
+
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		intValue, err := strconv.Atoi(value)
+		if err != nil {
+			log.Printf("Invalid value %s in %s, leaving it out", value, key)
+			continue
+		}
+		values = append(values, intValue)
+	}
+	return values
+}
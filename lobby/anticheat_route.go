@@ -0,0 +1,114 @@
+package lobby
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"lobby/response_codes"
+	"ludo/anticheat"
+	"metagame/gameserver/config"
+	"net/http"
+	"strconv"
+)
+
+// AntiCheatHandler serves the anti-cheat reports of the finished cash boards to the admins
+type AntiCheatHandler struct{}
+
+type ReportListResponse struct {
+	Code    string                   `json:"code"`
+	Message string                   `json:"message"`
+	Reports []anticheat.ReportSchema `json:"reports"`
+}
+
+type ReportResponse struct {
+	Code    string                  `json:"code"`
+	Message string                  `json:"message"`
+	Report  *anticheat.ReportSchema `json:"report,omitempty"`
+}
+
+// authorize checks the admin key of the request and answers it when the key is missing or wrong
+func (h *AntiCheatHandler) authorize(w http.ResponseWriter, r *http.Request) bool {
+	adminApiKey := config.GetConfig().AdminApiKey
+
+	if adminApiKey != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Admin-Key")), []byte(adminApiKey)) == 1 {
+		return true
+	}
+
+	responseCodes := response_codes.GetResponseCodeDetails("UNAUTHORIZED")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	json.NewEncoder(w).Encode(ReportResponse{Code: responseCodes.Code, Message: responseCodes.Message})
+	return false
+}
+
+// GetReports returns the latest reports, riskiest first.
+// Query parameters: flagged=true for the flagged ones only, playerId for the ones of a player, limit (100 by default).
+func (h *AntiCheatHandler) GetReports(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	var response ReportListResponse
+
+	limit, err := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+	if err != nil || limit <= 0 {
+		limit = 100
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	reports, err := anticheat.NewReportDAO().GetReports(r.URL.Query().Get("flagged") == "true", r.URL.Query().Get("playerId"), limit)
+	if err != nil {
+		responseCodes := response_codes.GetResponseCodeDetails("")
+
+		response.Code = responseCodes.Code
+		response.Message = responseCodes.Message
+
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	responseCodes := response_codes.GetResponseCodeDetails("REPORTS_FETCHED_SUCCESSFULLY")
+
+	response.Code = responseCodes.Code
+	response.Message = responseCodes.Message
+	response.Reports = reports
+
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetReport returns the report of the board given by the boardId query parameter
+func (h *AntiCheatHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	var response ReportResponse
+
+	w.Header().Set("Content-Type", "application/json")
+
+	report, err := anticheat.NewReportDAO().GetReportByBoardId(r.URL.Query().Get("boardId"))
+	if err != nil || report == nil {
+		key, status := "REPORT_NOT_FOUND", http.StatusNotFound
+		if err != nil {
+			key, status = "", http.StatusInternalServerError
+		}
+		responseCodes := response_codes.GetResponseCodeDetails(key)
+
+		response.Code = responseCodes.Code
+		response.Message = responseCodes.Message
+
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	responseCodes := response_codes.GetResponseCodeDetails("REPORT_FETCHED_SUCCESSFULLY")
+
+	response.Code = responseCodes.Code
+	response.Message = responseCodes.Message
+	response.Report = report
+
+	json.NewEncoder(w).Encode(response)
+}
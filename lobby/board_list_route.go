@@ -0,0 +1,59 @@
+package lobby
+
+import (
+	"encoding/json"
+	"lobby/response_codes"
+	"messaging/common"
+	"net/http"
+	"sort"
+)
+
+// BoardListHandler lists the boards of every game service registered on the server
+type BoardListHandler struct {
+	gameServices map[string]common.GameService
+}
+
+type GameBoardListResponse struct {
+	Code    string                `json:"code"`
+	Message string                `json:"message"`
+	Boards  []common.BoardSummary `json:"boards"`
+}
+
+// GetBoards returns the boards waiting for players or being played.
+// Query parameters: game for the boards of one game, all the games by default.
+func (h *BoardListHandler) GetBoards(w http.ResponseWriter, r *http.Request) {
+	response := GameBoardListResponse{Boards: []common.BoardSummary{}}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	games := []string{}
+	if game := r.URL.Query().Get("game"); game != "" {
+		if _, exists := h.gameServices[game]; !exists {
+			responseCodes := response_codes.GetResponseCodeDetails("GAME_NOT_FOUND")
+
+			response.Code = responseCodes.Code
+			response.Message = responseCodes.Message
+
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(response)
+			return
+		}
+		games = append(games, game)
+	} else {
+		for game := range h.gameServices {
+			games = append(games, game)
+		}
+		sort.Strings(games)
+	}
+
+	for _, game := range games {
+		response.Boards = append(response.Boards, h.gameServices[game].ListBoards()...)
+	}
+
+	responseCodes := response_codes.GetResponseCodeDetails("BOARD_LIST_FETCHED_SUCCESSFULLY")
+
+	response.Code = responseCodes.Code
+	response.Message = responseCodes.Message
+
+	json.NewEncoder(w).Encode(response)
+}
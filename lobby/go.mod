@@ -2,15 +2,22 @@ module lobby
 
 go 1.23.2
 
-require ludo v0.0.0
+require (
+	ludo v0.0.0
+	messaging v0.0.0
+	metagame/gameserver v0.0.0-00010101000000-000000000000
+)
 
 require (
 	github.com/golang-jwt/jwt/v5 v5.2.1 // indirect
 	github.com/golang/snappy v0.0.4 // indirect
+	github.com/google/uuid v1.6.0 // indirect
 	github.com/gorilla/websocket v1.5.3 // indirect
 	github.com/joho/godotenv v1.5.1 // indirect
 	github.com/klauspost/compress v1.13.6 // indirect
 	github.com/montanaflynn/stats v0.7.1 // indirect
+	github.com/vmihailenco/msgpack/v5 v5.4.1 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
 	github.com/xdg-go/pbkdf2 v1.0.0 // indirect
 	github.com/xdg-go/scram v1.1.2 // indirect
 	github.com/xdg-go/stringprep v1.0.4 // indirect
@@ -19,8 +26,6 @@ require (
 	golang.org/x/crypto v0.26.0 // indirect
 	golang.org/x/sync v0.8.0 // indirect
 	golang.org/x/text v0.17.0 // indirect
-	messaging v0.0.0 // indirect
-	metagame/gameserver v0.0.0-00010101000000-000000000000 // indirect
 	rng v0.0.0 // indirect
 )
 
@@ -1,14 +1,22 @@
 package lobby
 
 import (
+	"messaging/common"
 	"net/http"
 )
 
 type LobbyRouteHandler struct {
+	GameServices map[string]common.GameService // Game services registered on the server, by game name
 }
 
 func (s *LobbyRouteHandler) HandleRoutes() {
 	ludoGameHandler := &LudoGameHandler{}
+	antiCheatHandler := &AntiCheatHandler{}
+	boardListHandler := &BoardListHandler{gameServices: s.GameServices}
 
 	http.HandleFunc("/api/ludo/board-list", ludoGameHandler.GetBoardList)
+	http.HandleFunc("/api/ludo/board-detail", ludoGameHandler.GetBoardDetail)
+	http.HandleFunc("/api/boards", boardListHandler.GetBoards)
+	http.HandleFunc("/api/admin/anticheat/reports", antiCheatHandler.GetReports)
+	http.HandleFunc("/api/admin/anticheat/report", antiCheatHandler.GetReport)
 }
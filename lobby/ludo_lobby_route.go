@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"lobby/response_codes"
 	"ludo"
+	"ludo/board"
 	"ludo/ludo_board_constants"
+	"ludo/pawn"
 	"net/http"
+	"time"
 	// "ludo"
 )
 
@@ -14,6 +17,7 @@ type LudoGameHandler struct{}
 type Player struct {
 	PlayerId string `json:"playerId"`
 	Name     string `json:"name"`
+	IsBot    bool   `json:"isBot"`
 }
 
 type BoardResult struct {
@@ -23,6 +27,23 @@ type BoardResult struct {
 	Status                     string   `json:"status"`
 	AutoPlay                   bool     `json:"autoPlay"`
 	TicketAmount               int      `json:"ticketAmount"`
+	RuleSet                    string   `json:"ruleSet"`
+	PawnsPerQuadrant           int      `json:"pawnsPerQuadrant"`
+	Layout                     string   `json:"layout"`
+}
+
+type BoardDetail struct {
+	BoardResult
+	CurrentTurn      string                 `json:"currentTurn"`
+	Positions        []pawn.PawnPositions   `json:"positions"`
+	WinProbabilities board.WinProbabilities `json:"winProbabilities"`
+	EndsAt           *time.Time             `json:"endsAt,omitempty"` // Only for timed boards that started
+}
+
+type BoardDetailResponse struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Board   *BoardDetail `json:"board,omitempty"`
 }
 
 type BoardListResponse struct {
@@ -40,28 +61,14 @@ func (h *LudoGameHandler) GetBoardList(w http.ResponseWriter, r *http.Request) {
 	boardList := ludo.GetBoardList()
 
 	for _, board := range boardList {
+		board.Lock()
 
-		if board.GetBoardStatus() != ludo_board_constants.WAITING && board.GetBoardStatus() != ludo_board_constants.PLAYING {
-			continue
-		}
-
-		players := []Player{}
-
-		for _, player := range board.GetPlayers() {
-			players = append(players, Player{
-				PlayerId: player.ID,
-				Name:     player.Name,
-			})
+		// The boards closed by the shutdown stay listed, as UNAVAILABLE
+		if board.GetBoardStatus() == ludo_board_constants.WAITING || board.GetBoardStatus() == ludo_board_constants.PLAYING || board.GetBoardStatus() == ludo_board_constants.UNAVAILABLE {
+			response.Boards = append(response.Boards, buildBoardResult(board))
 		}
 
-		response.Boards = append(response.Boards, BoardResult{
-			BoardId:                    board.GetID(),
-			Players:                    players,
-			PlayersRequiredToStartGame: board.GetMaxPlayers(),
-			Status:                     string(board.GetBoardStatus()),
-			AutoPlay:                   board.GetAutoPlay(),
-			TicketAmount:               board.GetTicketAmount(),
-		})
+		board.Unlock()
 	}
 
 	responseCodes := response_codes.GetResponseCodeDetails("BOARD_LIST_FETCHED_SUCCESSFULLY")
@@ -72,3 +79,74 @@ func (h *LudoGameHandler) GetBoardList(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// GetBoardDetail returns the live state of a board, with the latest win probability estimate
+func (h *LudoGameHandler) GetBoardDetail(w http.ResponseWriter, r *http.Request) {
+
+	var response BoardDetailResponse
+
+	ludo := &ludo.LudoGameService{}
+
+	boardInstance := ludo.GetBoard(r.URL.Query().Get("boardId"))
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if boardInstance == nil {
+		responseCodes := response_codes.GetResponseCodeDetails("BOARD_NOT_FOUND")
+
+		response.Code = responseCodes.Code
+		response.Message = responseCodes.Message
+
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	boardInstance.Lock()
+	defer boardInstance.Unlock()
+
+	// The estimate of the current position is sent to the spectators and returned by the next detail request
+	boardInstance.RequestWinProbabilities()
+
+	response.Board = &BoardDetail{
+		BoardResult:      buildBoardResult(boardInstance),
+		CurrentTurn:      boardInstance.GetCurrentTurn(),
+		Positions:        boardInstance.GetPawnsPositionsInTheBoard(),
+		WinProbabilities: boardInstance.GetWinProbabilities(),
+	}
+
+	if endsAt := boardInstance.GetEndsAt(); !endsAt.IsZero() {
+		response.Board.EndsAt = &endsAt
+	}
+
+	responseCodes := response_codes.GetResponseCodeDetails("BOARD_DETAIL_FETCHED_SUCCESSFULLY")
+
+	response.Code = responseCodes.Code
+	response.Message = responseCodes.Message
+
+	json.NewEncoder(w).Encode(response)
+}
+
+func buildBoardResult(board *board.Board) BoardResult {
+	players := []Player{}
+
+	for _, player := range board.GetPlayers() {
+		players = append(players, Player{
+			PlayerId: player.ID,
+			Name:     player.Name,
+			IsBot:    player.IsBotPlayer(),
+		})
+	}
+
+	return BoardResult{
+		BoardId:                    board.GetID(),
+		Players:                    players,
+		PlayersRequiredToStartGame: board.GetMaxPlayers(),
+		Status:                     string(board.GetBoardStatus()),
+		AutoPlay:                   board.GetAutoPlay(),
+		TicketAmount:               board.GetTicketAmount(),
+		RuleSet:                    board.GetRuleSet().Name,
+		PawnsPerQuadrant:           board.GetPawnsPerQuadrant(),
+		Layout:                     board.GetGeometry().Layout,
+	}
+}
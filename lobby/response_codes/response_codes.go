@@ -1,5 +1,7 @@
 package response_codes
 
+import "messaging/common"
+
 type ResponseCodeDetails struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
@@ -10,6 +12,44 @@ var ResponseCodes = map[string]ResponseCodeDetails{
 		Code:    "B200",
 		Message: "Board list fetched successfully",
 	},
+	"BOARD_DETAIL_FETCHED_SUCCESSFULLY": {
+		Code:    "B201",
+		Message: "Board detail fetched successfully",
+	},
+	"BOARD_NOT_FOUND": {
+		Code:    "B404",
+		Message: "Board not found",
+	},
+	"GAME_NOT_FOUND": {
+		Code:    "G404",
+		Message: "Game is not hosted on this server",
+	},
+	"REPORTS_FETCHED_SUCCESSFULLY": {
+		Code:    "A200",
+		Message: "Anti-cheat reports fetched successfully",
+	},
+	"REPORT_FETCHED_SUCCESSFULLY": {
+		Code:    "A201",
+		Message: "Anti-cheat report fetched successfully",
+	},
+	"UNAUTHORIZED": {
+		Code:    "A401",
+		Message: "Missing or invalid admin key",
+	},
+	"REPORT_NOT_FOUND": {
+		Code:    "A404",
+		Message: "Board was not analyzed",
+	},
+}
+
+// The error codes the socket sends share the catalog, so REST and socket clients read the same codes
+func init() {
+	for key, details := range common.ErrorCodes {
+		ResponseCodes[string(key)] = ResponseCodeDetails{
+			Code:    details.Code,
+			Message: details.Message,
+		}
+	}
 }
 
 // Helper function to get response detail
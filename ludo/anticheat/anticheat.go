@@ -0,0 +1,257 @@
+package anticheat
+
+import (
+	"fmt"
+	"log"
+	"ludo/board"
+	"ludo/ludo_board_constants"
+	"ludo/player"
+	"math"
+	"slices"
+	"time"
+)
+
+// CoSeatings returns how many cash boards two players sat on together recently, the analyzed one included
+type CoSeatings func(playerA string, playerB string) int
+
+// AnalyzeFinishedBoards analyzes the finished cash boards the analysis has not run on yet and stores their reports
+func AnalyzeFinishedBoards() {
+	boardDAO := board.NewBoardDAO()
+	reportDAO := NewReportDAO()
+
+	games, err := boardDAO.GetBoardsToAnalyze(ludo_board_constants.ANTICHEAT_BATCH_SIZE)
+	if err != nil {
+		log.Printf("[AntiCheat] Failed to load the boards to analyze: %v", err)
+		return
+	}
+
+	since := time.Now().Add(-ludo_board_constants.ANTICHEAT_CO_SEATING_WINDOW)
+	coSeatings := func(playerA string, playerB string) int {
+		count, err := boardDAO.CountCoSeatings(playerA, playerB, since)
+		if err != nil {
+			log.Printf("[AntiCheat] %v", err)
+		}
+		return count
+	}
+
+	for _, game := range games {
+		report := Analyze(game, coSeatings)
+
+		if err := reportDAO.SaveReport(report); err != nil {
+			continue
+		}
+
+		if err := boardDAO.MarkBoardAnalyzed(game.BoardId); err != nil {
+			log.Printf("[AntiCheat] %v", err)
+		}
+
+		if report.Flagged {
+			log.Printf("[AntiCheat] Board %s flagged with risk score %d", game.BoardId, report.RiskScore)
+		}
+	}
+}
+
+// Analyze looks for cheating and collusion patterns among the human players of a finished board.
+// Parameters:
+//   - game: The finished board with its seats and move history
+//   - coSeatings: Counts the recent boards of a pair of players, nil to skip the signal
+//
+// Returns:
+//   - ReportSchema: The signals found and the risk score of every human player
+func Analyze(game board.BoardSchema, coSeatings CoSeatings) ReportSchema {
+	var humans []player.PlayerSchema
+	for _, seat := range game.Players {
+		if !seat.IsBot {
+			humans = append(humans, seat)
+		}
+	}
+
+	signals := []Signal{}
+	signals = append(signals, seatSignals(humans, coSeatings)...)
+	signals = append(signals, declinedCaptureSignals(game.Moves, humans)...)
+	signals = append(signals, reactionSignals(game.Moves, humans)...)
+
+	report := ReportSchema{
+		BoardId:      game.BoardId,
+		TicketAmount: game.TicketAmount,
+		Players:      []PlayerRisk{},
+		Signals:      signals,
+		AnalyzedAt:   time.Now(),
+	}
+
+	for _, seat := range humans {
+		risk := 0
+		for _, signal := range signals {
+			if slices.Contains(signal.Players, seat.PlayerID) {
+				risk += signal.Score
+			}
+		}
+		risk = min(risk, MAX_RISK_SCORE)
+
+		report.Players = append(report.Players, PlayerRisk{
+			PlayerId:  seat.PlayerID,
+			Quadrant:  seat.Quadrant,
+			RiskScore: risk,
+		})
+		report.RiskScore = max(report.RiskScore, risk)
+	}
+
+	report.Flagged = report.RiskScore >= ludo_board_constants.ANTICHEAT_FLAG_SCORE
+
+	return report
+}
+
+// seatSignals compares every pair of seats: the same wallet or IP, or players who keep sitting together
+func seatSignals(humans []player.PlayerSchema, coSeatings CoSeatings) []Signal {
+	var signals []Signal
+
+	for i, a := range humans {
+		for _, b := range humans[i+1:] {
+			pair := []string{a.PlayerID, b.PlayerID}
+
+			if a.WalletAddress != "" && a.WalletAddress == b.WalletAddress {
+				signals = append(signals, Signal{
+					Type:    SIGNAL_SHARED_WALLET,
+					Players: pair,
+					Score:   SHARED_WALLET_SCORE,
+					Detail:  fmt.Sprintf("%s and %s paid from wallet %s", a.Quadrant, b.Quadrant, a.WalletAddress),
+				})
+			}
+
+			if a.IPAddress != "" && a.IPAddress == b.IPAddress {
+				signals = append(signals, Signal{
+					Type:    SIGNAL_SHARED_IP,
+					Players: pair,
+					Score:   SHARED_IP_SCORE,
+					Detail:  fmt.Sprintf("%s and %s connected from %s", a.Quadrant, b.Quadrant, a.IPAddress),
+				})
+			}
+
+			if coSeatings == nil {
+				continue
+			}
+
+			if count := coSeatings(a.PlayerID, b.PlayerID); count > ludo_board_constants.ANTICHEAT_CO_SEATING_ALLOWED {
+				extra := count - ludo_board_constants.ANTICHEAT_CO_SEATING_ALLOWED - 1
+				signals = append(signals, Signal{
+					Type:    SIGNAL_REPEATED_CO_SEATING,
+					Players: pair,
+					Score:   min(CO_SEATING_SCORE+CO_SEATING_EXTRA_SCORE*extra, MAX_CO_SEATING_SCORE),
+					Detail:  fmt.Sprintf("%s and %s shared %d cash boards in %s", a.Quadrant, b.Quadrant, count, ludo_board_constants.ANTICHEAT_CO_SEATING_WINDOW),
+				})
+			}
+		}
+	}
+
+	return signals
+}
+
+// declinedCaptureSignals finds players who spare the pawns of one opponent much more often than a player
+// picking the best move would. Moves picked by the server or a bot are left out.
+func declinedCaptureSignals(moves []board.MoveRecordSchema, humans []player.PlayerSchema) []Signal {
+	playerByQuadrant := map[string]string{}
+	for _, seat := range humans {
+		playerByQuadrant[seat.Quadrant] = seat.PlayerID
+	}
+
+	type pair struct{ mover, victim string }
+	opportunities := map[pair]int{}
+	declined := map[pair]int{}
+	var pairs []pair
+
+	for _, move := range moves {
+		if move.AutoPlayed {
+			continue
+		}
+		for _, victim := range move.CapturableQuadrants {
+			if _, ok := playerByQuadrant[victim]; !ok || victim == move.Quadrant {
+				continue
+			}
+
+			key := pair{mover: move.PlayerId, victim: victim}
+			if opportunities[key] == 0 {
+				pairs = append(pairs, key)
+			}
+			opportunities[key]++
+			if !slices.Contains(move.CapturedQuadrants, victim) {
+				declined[key]++
+			}
+		}
+	}
+
+	var signals []Signal
+	for _, key := range pairs {
+		if opportunities[key] < ludo_board_constants.ANTICHEAT_MIN_OPPORTUNITIES {
+			continue
+		}
+		if float64(declined[key])/float64(opportunities[key]) < ludo_board_constants.ANTICHEAT_DECLINE_RATE {
+			continue
+		}
+
+		signals = append(signals, Signal{
+			Type:    SIGNAL_DECLINED_CAPTURES,
+			Players: []string{key.mover, playerByQuadrant[key.victim]},
+			Score:   min(DECLINED_CAPTURE_SCORE*declined[key], MAX_DECLINED_CAPTURES_SCORE),
+			Detail:  fmt.Sprintf("declined %d of %d captures of %s", declined[key], opportunities[key], key.victim),
+		})
+	}
+
+	return signals
+}
+
+// reactionSignals finds players whose moves come too fast or at too steady a pace to be picked by a human
+func reactionSignals(moves []board.MoveRecordSchema, humans []player.PlayerSchema) []Signal {
+	var signals []Signal
+
+	for _, seat := range humans {
+		var reactions []float64
+		for _, move := range moves {
+			if move.PlayerId == seat.PlayerID && !move.AutoPlayed && move.ReactionMs > 0 {
+				reactions = append(reactions, float64(move.ReactionMs))
+			}
+		}
+
+		if len(reactions) < ludo_board_constants.ANTICHEAT_MIN_REACTIONS {
+			continue
+		}
+
+		slices.Sort(reactions)
+		median := reactions[len(reactions)/2]
+
+		if median < float64(ludo_board_constants.ANTICHEAT_FAST_REACTION.Milliseconds()) {
+			signals = append(signals, Signal{
+				Type:    SIGNAL_FAST_REACTIONS,
+				Players: []string{seat.PlayerID},
+				Score:   FAST_REACTIONS_SCORE,
+				Detail:  fmt.Sprintf("median reaction time of %.0fms over %d moves", median, len(reactions)),
+			})
+		}
+
+		mean, deviation := meanAndDeviation(reactions)
+		if mean > 0 && deviation/mean < ludo_board_constants.ANTICHEAT_UNIFORM_VARIATION {
+			signals = append(signals, Signal{
+				Type:    SIGNAL_UNIFORM_REACTIONS,
+				Players: []string{seat.PlayerID},
+				Score:   UNIFORM_REACTIONS_SCORE,
+				Detail:  fmt.Sprintf("reaction times of %.0fms ± %.0fms over %d moves", mean, deviation, len(reactions)),
+			})
+		}
+	}
+
+	return signals
+}
+
+func meanAndDeviation(values []float64) (float64, float64) {
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+
+	variance := 0.0
+	for _, value := range values {
+		variance += (value - mean) * (value - mean)
+	}
+
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
@@ -0,0 +1,78 @@
+package anticheat
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"metagame/gameserver/config"
+	"metagame/gameserver/helpers"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReportDAO struct {
+	collection *mongo.Collection
+}
+
+func NewReportDAO() *ReportDAO {
+	client := helpers.GetMongoClient()
+	collection := client.Database(config.GetConfig().Database).Collection("anticheat_reports")
+	return &ReportDAO{
+		collection: collection,
+	}
+}
+
+// SaveReport stores the report, replacing a previous analysis of the same board
+func (dao *ReportDAO) SaveReport(report ReportSchema) error {
+	filter := bson.M{"boardId": report.BoardId}
+
+	_, err := dao.collection.ReplaceOne(context.Background(), filter, report, options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Printf("SaveReport: Error saving report of boardId %s: %v", report.BoardId, err)
+		return fmt.Errorf("failed to save report of game with ID %s: %v", report.BoardId, err)
+	}
+
+	return nil
+}
+
+// GetReports returns the latest reports, riskiest first, only the flagged ones or the ones of a player when asked
+func (dao *ReportDAO) GetReports(flaggedOnly bool, playerId string, limit int64) ([]ReportSchema, error) {
+	filter := bson.M{}
+	if flaggedOnly {
+		filter["flagged"] = true
+	}
+	if playerId != "" {
+		filter["players.playerId"] = playerId
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "riskScore", Value: -1}, {Key: "analyzedAt", Value: -1}}).SetLimit(limit)
+
+	cursor, err := dao.collection.Find(context.Background(), filter, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reports: %v", err)
+	}
+
+	reports := []ReportSchema{}
+	if err := cursor.All(context.Background(), &reports); err != nil {
+		return nil, fmt.Errorf("failed to decode reports: %v", err)
+	}
+
+	return reports, nil
+}
+
+// GetReportByBoardId returns the report of the board, nil if it was not analyzed
+func (dao *ReportDAO) GetReportByBoardId(boardId string) (*ReportSchema, error) {
+	var report ReportSchema
+
+	err := dao.collection.FindOne(context.Background(), bson.M{"boardId": boardId}).Decode(&report)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find report of game with ID %s: %v", boardId, err)
+	}
+
+	return &report, nil
+}
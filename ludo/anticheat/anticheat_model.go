@@ -0,0 +1,55 @@
+package anticheat
+
+import "time"
+
+// SignalType is a pattern of a finished board that hints at cheating or collusion
+type SignalType string
+
+const (
+	SIGNAL_DECLINED_CAPTURES   SignalType = "DECLINED_CAPTURES"   // A player keeps sparing the pawns of the same opponent
+	SIGNAL_SHARED_WALLET       SignalType = "SHARED_WALLET"       // Two seats were paid from the same wallet
+	SIGNAL_SHARED_IP           SignalType = "SHARED_IP"           // Two seats connected from the same IP
+	SIGNAL_FAST_REACTIONS      SignalType = "FAST_REACTIONS"      // Moves come faster than a human picks them
+	SIGNAL_UNIFORM_REACTIONS   SignalType = "UNIFORM_REACTIONS"   // Moves come at a steadier pace than a human picks them
+	SIGNAL_REPEATED_CO_SEATING SignalType = "REPEATED_CO_SEATING" // Two players keep sitting at the same cash boards
+)
+
+// Risk added by each signal to the players it involves
+const (
+	SHARED_WALLET_SCORE         = 60
+	SHARED_IP_SCORE             = 30
+	DECLINED_CAPTURE_SCORE      = 10 // For every declined capture
+	MAX_DECLINED_CAPTURES_SCORE = 40
+	FAST_REACTIONS_SCORE        = 20
+	UNIFORM_REACTIONS_SCORE     = 15
+	CO_SEATING_SCORE            = 20
+	CO_SEATING_EXTRA_SCORE      = 2 // For every shared board past the first suspicious one
+	MAX_CO_SEATING_SCORE        = 40
+	MAX_RISK_SCORE              = 100
+)
+
+// Signal is a suspicious pattern found on a board and the players it involves
+type Signal struct {
+	Type    SignalType `bson:"type" json:"type"`
+	Players []string   `bson:"players" json:"players"`
+	Score   int        `bson:"score" json:"score"`
+	Detail  string     `bson:"detail" json:"detail"`
+}
+
+// PlayerRisk is the risk score of a player of the board, the sum of the signals involving it
+type PlayerRisk struct {
+	PlayerId  string `bson:"playerId" json:"playerId"`
+	Quadrant  string `bson:"quadrant" json:"quadrant"`
+	RiskScore int    `bson:"riskScore" json:"riskScore"`
+}
+
+// ReportSchema is the anti-cheat analysis of a finished board, as stored in the anticheat_reports collection
+type ReportSchema struct {
+	BoardId      string       `bson:"boardId" json:"boardId"`
+	TicketAmount int          `bson:"ticketAmount" json:"ticketAmount"`
+	Players      []PlayerRisk `bson:"players" json:"players"`
+	Signals      []Signal     `bson:"signals" json:"signals"`
+	RiskScore    int          `bson:"riskScore" json:"riskScore"` // Highest risk score of the players
+	Flagged      bool         `bson:"flagged" json:"flagged"`
+	AnalyzedAt   time.Time    `bson:"analyzedAt" json:"analyzedAt"`
+}
@@ -0,0 +1,77 @@
+package anticheat
+
+import (
+	"ludo/board"
+	"ludo/player"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeFlagsColludingSeats(t *testing.T) {
+	game := board.BoardSchema{
+		BoardId:      "board",
+		TicketAmount: 100,
+		Players: []player.PlayerSchema{
+			{PlayerID: "alice", Quadrant: "QUADRANT_1", WalletAddress: "w1", IPAddress: "10.0.0.1"},
+			{PlayerID: "bob", Quadrant: "QUADRANT_2", WalletAddress: "w2", IPAddress: "10.0.0.1"},
+			{PlayerID: "carol", Quadrant: "QUADRANT_3", WalletAddress: "w3", IPAddress: "10.0.0.3"},
+			{PlayerID: "bot", Quadrant: "QUADRANT_4", IsBot: true},
+		},
+	}
+
+	// Alice spares Bob every time but captures Carol, and captures by the server do not count
+	for i := 0; i < 4; i++ {
+		game.Moves = append(game.Moves,
+			board.MoveRecordSchema{PlayerId: "alice", Quadrant: "QUADRANT_1", CapturableQuadrants: []string{"QUADRANT_2"}, ReactionMs: int64(900 + 300*i)},
+			board.MoveRecordSchema{PlayerId: "alice", Quadrant: "QUADRANT_1", CapturableQuadrants: []string{"QUADRANT_3"}, CapturedQuadrants: []string{"QUADRANT_3"}, ReactionMs: int64(1200 + 200*i)},
+			board.MoveRecordSchema{PlayerId: "carol", Quadrant: "QUADRANT_3", CapturableQuadrants: []string{"QUADRANT_2"}, AutoPlayed: true},
+		)
+	}
+	// Bob answers every roll right away
+	for i := 0; i < 10; i++ {
+		game.Moves = append(game.Moves, board.MoveRecordSchema{PlayerId: "bob", Quadrant: "QUADRANT_2", ReactionMs: 120})
+	}
+
+	report := Analyze(game, func(playerA string, playerB string) int {
+		if playerA == "alice" && playerB == "bob" {
+			return 9
+		}
+		return 1
+	})
+
+	var types []SignalType
+	for _, signal := range report.Signals {
+		types = append(types, signal.Type)
+	}
+	assert.ElementsMatch(t, []SignalType{SIGNAL_SHARED_IP, SIGNAL_REPEATED_CO_SEATING, SIGNAL_DECLINED_CAPTURES, SIGNAL_FAST_REACTIONS, SIGNAL_UNIFORM_REACTIONS}, types)
+
+	assert.Equal(t, []PlayerRisk{
+		{PlayerId: "alice", Quadrant: "QUADRANT_1", RiskScore: 30 + 26 + 40},
+		{PlayerId: "bob", Quadrant: "QUADRANT_2", RiskScore: 100},
+		{PlayerId: "carol", Quadrant: "QUADRANT_3", RiskScore: 0},
+	}, report.Players, "Bots should not be scored and risks should be capped")
+	assert.Equal(t, 100, report.RiskScore)
+	assert.True(t, report.Flagged)
+}
+
+func TestAnalyzeLeavesFairBoardsUnflagged(t *testing.T) {
+	game := board.BoardSchema{
+		BoardId: "board",
+		Players: []player.PlayerSchema{
+			{PlayerID: "alice", Quadrant: "QUADRANT_1", WalletAddress: "w1", IPAddress: "10.0.0.1"},
+			{PlayerID: "bob", Quadrant: "QUADRANT_2", WalletAddress: "w2", IPAddress: "10.0.0.2"},
+		},
+		Moves: []board.MoveRecordSchema{
+			{PlayerId: "alice", Quadrant: "QUADRANT_1", CapturableQuadrants: []string{"QUADRANT_2"}, ReactionMs: 1500},
+			{PlayerId: "alice", Quadrant: "QUADRANT_1", CapturableQuadrants: []string{"QUADRANT_2"}, CapturedQuadrants: []string{"QUADRANT_2"}, ReactionMs: 800},
+			{PlayerId: "alice", Quadrant: "QUADRANT_1", CapturableQuadrants: []string{"QUADRANT_2"}, CapturedQuadrants: []string{"QUADRANT_2"}, ReactionMs: 2500},
+		},
+	}
+
+	report := Analyze(game, nil)
+
+	assert.Empty(t, report.Signals)
+	assert.Equal(t, 0, report.RiskScore)
+	assert.False(t, report.Flagged)
+}
@@ -1,31 +1,32 @@
 package board
 
 import (
-	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"log"
 	"ludo/dice"
+	"ludo/engine"
 	"ludo/ludo_board_constants"
 	"ludo/pawn"
 	"ludo/player"
 	"ludo/quadrant"
+	"ludo/rules"
+	"math/rand"
 	"messaging/common"
-	"messaging/socket"
-	"metagame/gameserver/config"
-	"net/http"
+	"metagame/gameserver/wallet"
+	"rng"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // Board represents the game board containing quadrants and manages the game state
 type Board struct {
 	id                         string                           // Unique identifier for the game
-	quadrants                  []*quadrant.Quadrant             // Four quadrants (red, green, yellow, blue)
+	quadrants                  []*quadrant.Quadrant             // Quadrants of the layout, in turn order
 	players                    []*player.Player                 // List of players in the game
 	currentTurn                string                           // quadrant.Quadrant which has the current turn
 	safePositions              []int                            // List of safe positions on the board
@@ -38,16 +39,41 @@ type Board struct {
 	rakeAmountType             ludo_board_constants.RakeAmountType
 	playersRequiredToStartGame int
 	expectedMessage            *ExpectedMessage
+	lastExpectedMessage        *ExpectedMessage // Last expected message received, waited for again when a pawn move is rejected
+	violations                 map[string]int   // Rejected pawn moves by player, for anti-cheat review
+	autoPlaying                bool             // The server is playing the expected message for the player
 	diceRolledValue            int
+	waitingSince               time.Time    // When the first player joined the empty board
+	hintsEnabled               bool         // Send Board.Hint to the player who has to move
+	drained                    bool         // The server is shutting down, the game is frozen at its stored state
+	moveCount                  atomic.Int64 // Number of pawn moves played on the board, read by the estimator goroutine
+	winProbabilities           WinProbabilities
+	winProbabilitiesPending    bool         // An estimate is scheduled and has not started yet
+	winProbabilitiesMu         sync.RWMutex // Guards winProbabilities and winProbabilitiesPending, written by the estimator goroutine
+	mu                         sync.Mutex
+	ruleSet                    rules.RuleSet                   // House rules the board is played with
+	state                      rules.State                     // Game state once the game started, the quadrants and pawns mirror it
+	stateVersion               int                             // Incremented on every change of the game state
+	syncedPositions            []pawn.PawnPositions            // Positions last sent to the clients, the next messages carry the changes since
+	endsAt                     time.Time                       // When a timed board ends by score, zero for untimed boards
+	pawnsPerQuadrant           int                             // Pawns every quadrant plays with, fewer for faster games
+	startOrder                 ludo_board_constants.StartOrder // How the quadrant playing first is picked
+	firstTurn                  string                          // Quadrant that played first
+	geometry                   quadrant.QuadrantConfigSchema   // Layout of the board the quadrants were built from
+	rollDie                    func() int                      // Rolls a fair die, replaced by the tests
+	transport                  Transport
+	store                      Store
+	wallet                     Wallet
 }
 
 type ExpectedMessage struct {
-	EventName string
-	Quadrant  string
-	PlayerId  string
-	TStamp    time.Time
-	Timeout   time.Duration
-	Steps     int
+	EventName     string
+	Quadrant      string
+	PlayerId      string
+	TStamp        time.Time
+	Timeout       time.Duration
+	Steps         int
+	RemainingDice []int // Indexes of the dice the player can still move with
 }
 
 // NewBoard creates and initializes a new game board with the given players
@@ -56,40 +82,40 @@ type ExpectedMessage struct {
 //
 // Returns:
 //   - *Board: Pointer to the newly created Board
-func NewBoard(boardId string, playersRequiredToStartGame int, autoPlay bool, ticketAmount int, rakeAmount int, rakeAmountType ludo_board_constants.RakeAmountType, autoPlayTimer int) *Board {
-	quadrantConfigResult, err := quadrant.InitializeQuadrantConfigIfNotExists(ludo_board_constants.QuadrantsNames, ludo_board_constants.QuadrantsPaths, ludo_board_constants.QuadrantsColors, ludo_board_constants.SafePositions)
+//   - error: When the geometry of the board layout can't be loaded or the board can't be stored
+func NewBoard(boardId string, playersRequiredToStartGame int, autoPlay bool, ticketAmount int, rakeAmount int, rakeAmountType ludo_board_constants.RakeAmountType, autoPlayTimer int, ruleSet rules.RuleSet, pawnsPerQuadrant int) (*Board, error) {
+	layout, ok := ludo_board_constants.BOARD_LAYOUTS[playersRequiredToStartGame]
+	if !ok {
+		layout = ludo_board_constants.CLASSIC_LAYOUT
+	}
 
+	quadrantConfig, err := quadrant.InitializeQuadrantConfigIfNotExists(defaultQuadrantConfig(layout))
 	if err != nil {
-		fmt.Print("Error: ", err)
+		return nil, fmt.Errorf("failed to load the geometry of layout %s: %w", layout, err)
 	}
 
-	var quadrantConfig primitive.M
-
-	err = json.Unmarshal([]byte(quadrantConfigResult), &quadrantConfig)
+	board := newBoard(boardId, playersRequiredToStartGame, autoPlay, ticketAmount, rakeAmount, rakeAmountType, autoPlayTimer, ruleSet, pawnsPerQuadrant, quadrantConfig)
 
-	if err != nil {
-		fmt.Print("Error: ", err)
+	if err := board.store.InsertBoard(board.toSchema()); err != nil {
+		return nil, fmt.Errorf("failed to store board %s: %w", boardId, err)
 	}
 
-	quadrantsConfigMap, err := quadrant.ConvertToQuadrantMap(quadrantConfig)
-	if err != nil {
-		log.Fatalf("Failed to convert quadrant config: %v", err)
-	}
+	return board, nil
+}
 
-	quadrants := []*quadrant.Quadrant{
-		quadrant.NewQuadrant(quadrantsConfigMap["QUADRANT_1"].(map[string]interface{})["Color"].(string), nil, "QUADRANT_1", quadrantsConfigMap["QUADRANT_1"].(map[string]interface{})["Path"].([]int)),
-		quadrant.NewQuadrant(quadrantsConfigMap["QUADRANT_2"].(map[string]interface{})["Color"].(string), nil, "QUADRANT_2", quadrantsConfigMap["QUADRANT_2"].(map[string]interface{})["Path"].([]int)),
-		quadrant.NewQuadrant(quadrantsConfigMap["QUADRANT_3"].(map[string]interface{})["Color"].(string), nil, "QUADRANT_3", quadrantsConfigMap["QUADRANT_3"].(map[string]interface{})["Path"].([]int)),
-		quadrant.NewQuadrant(quadrantsConfigMap["QUADRANT_4"].(map[string]interface{})["Color"].(string), nil, "QUADRANT_4", quadrantsConfigMap["QUADRANT_4"].(map[string]interface{})["Path"].([]int)),
+// newBoard builds the board on the geometry, it is neither stored nor connected to anything but the defaults
+func newBoard(boardId string, playersRequiredToStartGame int, autoPlay bool, ticketAmount int, rakeAmount int, rakeAmountType ludo_board_constants.RakeAmountType, autoPlayTimer int, ruleSet rules.RuleSet, pawnsPerQuadrant int, quadrantConfig quadrant.QuadrantConfigSchema) *Board {
+	var quadrants []*quadrant.Quadrant
+	for _, quadrantSchema := range quadrantConfig.Quadrants {
+		quadrants = append(quadrants, quadrant.NewQuadrant(quadrantSchema.Color, nil, quadrantSchema.Name, quadrantSchema.Path, pawnsPerQuadrant))
 	}
 
-	newBoard := CreateBoardInDB(boardId, autoPlay, playersRequiredToStartGame, ticketAmount, rakeAmount, rakeAmountType, autoPlayTimer)
-
-	board := &Board{
-		id:                         newBoard["boardId"].(string),
+	return &Board{
+		id:                         boardId,
 		quadrants:                  quadrants,
 		players:                    []*player.Player{},
-		safePositions:              quadrantsConfigMap["SafePositions"].([]int),
+		safePositions:              quadrantConfig.SafePositions,
+		geometry:                   quadrantConfig,
 		ticketAmount:               ticketAmount,
 		rakeAmount:                 rakeAmount,
 		rakeAmountType:             rakeAmountType,
@@ -97,9 +123,92 @@ func NewBoard(boardId string, playersRequiredToStartGame int, autoPlay bool, tic
 		autoPlayTimer:              autoPlayTimer,
 		playersRequiredToStartGame: playersRequiredToStartGame,
 		status:                     ludo_board_constants.BoardStatus("WAITING"),
+		hintsEnabled:               ticketAmount == 0, // Practice tables are free to play
+		ruleSet:                    ruleSet,
+		pawnsPerQuadrant:           pawnsPerQuadrant,
+		transport:                  socketTransport{},
+		store:                      mongoStore{},
+		wallet:                     wallet.NewPlatformWallet(ludo_board_constants.GAME),
+		rollDie:                    (&dice.Dice{}).Roll,
+	}
+}
+
+// toSchema returns the board as it is first stored, before anyone joined
+func (b *Board) toSchema() BoardSchema {
+	return BoardSchema{
+		ID:                         primitive.NewObjectID().Hex(),
+		BoardId:                    b.id,
+		Status:                     ludo_board_constants.WAITING,
+		AutoPlay:                   b.autoPlay,
+		AutoPlayTimer:              b.autoPlayTimer,
+		TicketAmount:               b.ticketAmount,
+		RakeAmount:                 b.rakeAmount,
+		RakeAmountType:             b.rakeAmountType,
+		PlayersRequiredToStartGame: b.playersRequiredToStartGame,
+		RuleSet:                    b.ruleSet,
+		PawnsPerQuadrant:           b.pawnsPerQuadrant,
+		Layout:                     b.geometry.Layout,
+		StartTime:                  nil,
+		EndTime:                    nil,
+		Winner:                     nil,
+		Players:                    []player.PlayerSchema{},
+		PawnMoves:                  make(map[string]map[string][]MoveSchema),
+	}
+}
+
+// defaultQuadrantConfig returns the geometry the layout is created with when the database has none
+func defaultQuadrantConfig(layout string) quadrant.QuadrantConfigSchema {
+	if quadrants, ok := ludo_board_constants.RING_LAYOUTS[layout]; ok {
+		var names []string
+		for i := 1; i <= quadrants; i++ {
+			names = append(names, fmt.Sprintf("QUADRANT_%d", i))
+		}
+		return quadrant.NewRingQuadrantConfig(layout, names, ludo_board_constants.LayoutColors, ludo_board_constants.ARM_LENGTH, ludo_board_constants.HOME_COLUMN_LENGTH)
 	}
 
-	return board
+	return quadrant.NewQuadrantConfig(layout, ludo_board_constants.QuadrantsNames, ludo_board_constants.QuadrantsPaths, ludo_board_constants.QuadrantsColors, ludo_board_constants.SafePositions, ludo_board_constants.HOME_COLUMN_LENGTH, ludo_board_constants.GRID_SIZE)
+}
+
+// GetGeometry returns the layout of the board
+func (b *Board) GetGeometry() quadrant.QuadrantConfigSchema {
+	return b.geometry
+}
+
+// SetTransport replaces the websocket server as the way the board messages are delivered
+func (b *Board) SetTransport(transport Transport) {
+	b.transport = transport
+}
+
+// SetStore replaces MongoDB as the place the board is persisted
+func (b *Board) SetStore(store Store) {
+	b.store = store
+}
+
+// SetWallet replaces the platform wallet API for bets, wins and refunds
+func (b *Board) SetWallet(wallet Wallet) {
+	b.wallet = wallet
+}
+
+// Lock serialises everything that changes the board: player messages, autoplay and seating
+func (b *Board) Lock() {
+	b.mu.Lock()
+}
+
+func (b *Board) Unlock() {
+	b.mu.Unlock()
+}
+
+func (b *Board) SetHintsEnabled(hintsEnabled bool) {
+	b.hintsEnabled = hintsEnabled
+}
+
+func (b *Board) GetHintsEnabled() bool {
+	return b.hintsEnabled
+}
+
+// GetRuleSet returns the house rules the board is played with
+func (b *Board) GetRuleSet() rules.RuleSet {
+	return b.ruleSet
 }
 
 func (b *Board) GetMaxPlayers() int {
@@ -145,12 +254,59 @@ func (b *Board) HasFinished() bool {
 	return b.GetBoardStatus() == ludo_board_constants.FINISHED
 }
 
+// GetWaitingSince returns when the first player joined the board, zero if the board is empty
+func (b *Board) GetWaitingSince() time.Time {
+	if len(b.players) == 0 {
+		return time.Time{}
+	}
+	return b.waitingSince
+}
+
+// GetBotCount returns the number of bots seated on the board
+func (b *Board) GetBotCount() int {
+	count := 0
+	for _, p := range b.players {
+		if p.IsBotPlayer() {
+			count++
+		}
+	}
+	return count
+}
+
 // AddPlayer adds a new player to the game
 func (b *Board) AddPlayer(playerId string, name string, walletAddress string) error {
 	// log.Printf("Attempting to add player - ID: %s, Name: %s", playerId, name)
 
+	return b.addPlayer(&player.Player{
+		ID:               playerId,
+		PlayerId:         playerId,
+		Name:             name,
+		ConnectionStatus: player.PLAYER_CONNECTED,
+		WalletAddress:    walletAddress,
+	})
+}
+
+// AddBot seats a server-side bot on a WAITING board.
+// The bot must already be attached to the socket layer so it receives the board messages.
+func (b *Board) AddBot(botId string, name string) error {
+	if b.GetBoardStatus() != ludo_board_constants.WAITING {
+		return fmt.Errorf("bots can only join a waiting board")
+	}
+
+	return b.addPlayer(&player.Player{
+		ID:               botId,
+		PlayerId:         botId,
+		Name:             name,
+		ConnectionStatus: player.PLAYER_CONNECTED,
+		IsBot:            true,
+	})
+}
+
+func (b *Board) addPlayer(newPlayer *player.Player) error {
+	playerId := newPlayer.GetPlayerId()
+
 	if b.HasFinished() {
-		return fmt.Errorf("game has already finished. Not accepting new players")
+		return common.NewSocketError(common.BOARD_CLOSED, "game has already finished. Not accepting new players")
 	}
 
 	playerExists := b.GetPlayerByPlayerId(playerId)
@@ -175,24 +331,20 @@ func (b *Board) AddPlayer(playerId string, name string, walletAddress string) er
 	// log.Printf("[AddPlayer] Checking board status: %s", b.GetBoardStatus())
 	if b.GetBoardStatus() == ludo_board_constants.PLAYING {
 		// log.Printf("[AddPlayer] Rejected: Game already started for board %s", b.GetID())
-		return fmt.Errorf("game Already started. Not Accepting new players")
+		return common.NewSocketError(common.BOARD_CLOSED, "game Already started. Not Accepting new players")
 	}
 
 	// log.Printf("[AddPlayer] Current player count: %d, Max players: %d", len(b.GetPlayers()), b.GetMaxPlayers())
 	if len(b.GetPlayers()) == b.GetMaxPlayers() {
 		// log.Printf("[AddPlayer] Rejected: Maximum players reached for board %s", b.GetID())
-		return fmt.Errorf("game has reached maximum players. Not Accepting new players")
+		return common.NewSocketError(common.BOARD_FULL, "game has reached maximum players. Not Accepting new players")
 	}
 
-	player := &player.Player{
-		ID:               playerId,
-		PlayerId:         playerId,
-		Name:             name,
-		ConnectionStatus: player.PLAYER_CONNECTED,
-		WalletAddress:    walletAddress,
+	if len(b.players) == 0 {
+		b.waitingSince = time.Now()
 	}
 
-	b.players = append(b.players, player)
+	b.players = append(b.players, newPlayer)
 
 	// log.Printf("[AddPlayer] Created new player instance - ID: %s, Name: %s", player.ID, player.Name)
 
@@ -200,7 +352,7 @@ func (b *Board) AddPlayer(playerId string, name string, walletAddress string) er
 
 	b.SendGameInitializeMessage(playerId)
 
-	b.SendBoardWaitingPlayersMessage(player)
+	b.SendBoardWaitingPlayersMessage(newPlayer)
 
 	if b.playersRequiredToStartGame == len(b.players) {
 		log.Printf("[AddPlayer] All players have joined the board %s", b.GetID())
@@ -222,15 +374,16 @@ func (b *Board) HandleReconnection(existingPlayer *player.Player) error {
 
 	log.Printf(
 		"[AddPlayer] Player %s reconnected to the game. Player details: ID: %s, Name: %s, Quadrant: %s",
+		existingPlayer.GetPlayerId(), existingPlayer.ID, existingPlayer.GetName(), existingPlayer.GetQuadrant(),
 	)
 	existingPlayer.SetConnectionStatus(player.PLAYER_CONNECTED)
 	log.Printf("[AddPlayer] Player %s reconnected to the game and connection status is %d", existingPlayer.GetPlayerId(), existingPlayer.ConnectionStatus)
 
-	UpdatePlayerConnectionDetails(b.GetID(), existingPlayer.GetPlayerId(), "reconnection", time.Now())
+	b.store.UpdatePlayerConnectionDetails(b.GetID(), existingPlayer.GetPlayerId(), "reconnection", time.Now())
 
 	// log.Printf("[AddPlayer] Sending board reconnection message to player %s", playerId)
-	boardReconnectionMessage := NewBoardReconnectionMessage(b.BuildBoardJoinedMessage().participants, b.GetPawnsPositionsInTheBoard())
-	socket.SendMessage(existingPlayer.GetPlayerId(), boardReconnectionMessage, b.id)
+	boardReconnectionMessage := NewBoardReconnectionMessage(b.BuildBoardJoinedMessage().participants, b.Snapshot())
+	b.transport.Send(existingPlayer.GetPlayerId(), boardReconnectionMessage, b.id)
 
 	b.handleMessageAfterReconnection(*existingPlayer)
 
@@ -241,14 +394,14 @@ func (b *Board) handleMessageAfterReconnection(existingPlayer player.Player) {
 	if b.expectedMessage != nil && b.expectedMessage.PlayerId == existingPlayer.GetPlayerId() {
 		if b.expectedMessage.EventName == ludo_board_constants.BOARD_MOVEPAWN {
 			if quadrant := b.GetQuadrantFromPlayer(existingPlayer.GetPlayerId()); quadrant != nil && quadrant.GetName() == b.currentTurn {
-				diceRolledMessage := dice.NewDiceRolledMessage(ludo_board_constants.BOARD_DICEROLLED, b.diceRolledValue, existingPlayer.Quadrant, b.calculateMovablePawns(b.diceRolledValue))
-				b.SetExpectedMovePawnMessage(quadrant.GetName(), 30*time.Second, b.diceRolledValue)
+				diceRolledMessage := dice.NewDiceRolledMessage(ludo_board_constants.BOARD_DICEROLLED, b.diceRolledValue, existingPlayer.Quadrant, b.calculateMovablePawns(b.diceRolledValue), false, b.rolledValues(), b.movablePawnsByDie())
+				b.SetExpectedMovePawnMessage(quadrant.GetName(), 30*time.Second, b.diceRolledValue, b.state.RemainingDice())
 				b.broadCastMessage(diceRolledMessage)
 			}
 		}
 
 		if b.expectedMessage.EventName == ludo_board_constants.BOARD_DICEROLL || b.expectedMessage.EventName == ludo_board_constants.BOARD_TURN_COMPLETED {
-			turnMessage := NewTurnMessage(ludo_board_constants.TURN, b.currentTurn, b.GetPawnsPositionsInTheBoard())
+			turnMessage := NewTurnMessage(ludo_board_constants.TURN, b.currentTurn, b.stateSync())
 			b.SetExpectedDiceRollMessage(b.currentTurn, 30*time.Second)
 			b.broadCastMessage(turnMessage)
 		}
@@ -275,6 +428,7 @@ func (b *Board) HandleDisconnection(playerId string) error {
 	}
 
 	var disconnectedPlayer *player.Player
+	// Bots never disconnect, so the board is abandoned once every human has left
 	allDisconnected := true
 	var remainingPlayerId string
 	remainingPlayersCount := 0
@@ -286,12 +440,14 @@ func (b *Board) HandleDisconnection(playerId string) error {
 			// log.Printf("[HandleDisconnection] Found disconnecting player - Name: %s, ID: %s", p.GetName(), p.GetPlayerId())
 			disconnectedPlayer = p
 			disconnectedPlayer.SetConnectionStatus(player.PLAYER_DISCONNECTED)
-			UpdatePlayerConnectionDetails(b.GetID(), playerId, "disconnection", time.Now())
+			b.store.UpdatePlayerConnectionDetails(b.GetID(), playerId, "disconnection", time.Now())
 			// log.Printf("[HandleDisconnection] Marked player %s as disconnected", disconnectedPlayer.GetName())
 		}
 
 		if p.IsConnected() {
-			allDisconnected = false
+			if !p.IsBotPlayer() {
+				allDisconnected = false
+			}
 			remainingPlayerId = p.ID
 			remainingPlayersCount++
 			// log.Printf("[HandleDisconnection] Found connected player - ID: %s, Name: %s", p.GetPlayerId(), p.GetName())
@@ -300,7 +456,7 @@ func (b *Board) HandleDisconnection(playerId string) error {
 
 	// log.Printf("[HandleDisconnection] Broadcasting disconnection message for player %s", playerId)
 	disconnectionMessage := NewDisconnectionMessage(ludo_board_constants.PLAYER_DISCONNECTED, b.GetPlayerByPlayerId(playerId).Name)
-	socket.BroadcastMessage(disconnectionMessage, b.GetID())
+	b.broadCastMessage(disconnectionMessage)
 
 	if b.GetBoardStatus() != ludo_board_constants.FINISHED {
 		// log.Printf("[HandleDisconnection] Game not finished, checking conditions")
@@ -326,6 +482,13 @@ func (b *Board) HandleDisconnection(playerId string) error {
 				time.Sleep(30 * time.Second)
 				log.Printf("[HandleDisconnection] Timer completed, re-evaluating player count")
 
+				b.Lock()
+				defer b.Unlock()
+
+				if b.GetBoardStatus() != ludo_board_constants.PLAYING || b.drained {
+					return
+				}
+
 				connectedPlayersCount := 0
 				for _, p := range b.GetPlayers() {
 					log.Printf("[HandleDisconnection] Checking player %s - Connected: %t", p.GetName(), p.IsConnected())
@@ -389,6 +552,30 @@ func (b *Board) GetPlayerByPlayerId(playerId string) *player.Player {
 	return nil
 }
 
+// GetTeam returns the team of the quadrant, empty when the board is not played in teams
+func (b *Board) GetTeam(quadrant string) string {
+	if !b.ruleSet.Teams {
+		return ""
+	}
+	return ludo_board_constants.QuadrantsTeams[quadrant]
+}
+
+// getTeamPlayerIds returns the players of the team of the given player, only the player outside team games
+func (b *Board) getTeamPlayerIds(playerId string) []string {
+	team := b.GetTeam(b.GetPlayerByPlayerId(playerId).GetQuadrant())
+	if team == "" {
+		return []string{playerId}
+	}
+
+	var playerIds []string
+	for _, player := range b.players {
+		if b.GetTeam(player.GetQuadrant()) == team {
+			playerIds = append(playerIds, player.GetPlayerId())
+		}
+	}
+	return playerIds
+}
+
 func (b *Board) GetPlayerByQuadrant(quadrant string) *player.Player {
 	for _, player := range b.players {
 		if player.GetQuadrant() == quadrant {
@@ -398,6 +585,14 @@ func (b *Board) GetPlayerByQuadrant(quadrant string) *player.Player {
 	return nil
 }
 
+// getPlayerIdByQuadrant returns the id of the player of the quadrant, empty when the player has left the board
+func (b *Board) getPlayerIdByQuadrant(quadrant string) string {
+	if player := b.GetPlayerByQuadrant(quadrant); player != nil {
+		return player.GetPlayerId()
+	}
+	return ""
+}
+
 func (q *Board) GetQuadrantFromPlayer(playerId string) *quadrant.Quadrant {
 	for _, quadrant := range q.quadrants {
 		if quadrant.GetPlayer() != nil && quadrant.GetPlayer().GetPlayerId() == playerId {
@@ -432,7 +627,7 @@ func (b *Board) SelectQuadrant(playerId string, selectQuadrantMessage quadrant.Q
 
 			if err != nil {
 				newBoardBetFailedMessage := NewBoardBetFailedMessage(ludo_board_constants.BOARD_BET_FAILED, err.Error())
-				socket.SendMessage(playerId, newBoardBetFailedMessage, b.id)
+				b.transport.Send(playerId, newBoardBetFailedMessage, b.id)
 				b.RemovePlayer(playerId)
 				return nil, err
 			}
@@ -450,11 +645,16 @@ func (b *Board) SelectQuadrant(playerId string, selectQuadrantMessage quadrant.Q
 				PlayerID: playerId,
 				Quadrant: quadrantInstance.GetName(),
 				Name:     playerInstance.Name,
+				IsBot:    playerInstance.IsBotPlayer(),
 				JoinedAt: time.Now(),
 			}
+			if !playerInstance.IsBotPlayer() {
+				newPlayer.WalletAddress = playerInstance.WalletAddress
+				newPlayer.IPAddress = b.transport.RemoteAddress(playerId, b.id)
+			}
 
 			// Call the AddPlayerToDB function from the services package
-			err = AddPlayerToBoardInDB(b.id, *newPlayer)
+			err = b.store.AddPlayer(b.id, *newPlayer)
 
 			if err != nil {
 				return nil, err
@@ -473,14 +673,14 @@ func (b *Board) SelectQuadrant(playerId string, selectQuadrantMessage quadrant.Q
 			return playerInstance, nil
 		}
 	}
-	return nil, errors.New("quadrant.Quadrant not available")
+	return nil, common.NewSocketError(common.QUADRANT_TAKEN, "quadrant.Quadrant not available")
 }
 
 func (b *Board) startGameIfReady() {
 	if b.IsBoardReadyToStartGame() {
-		UpdateGameStatusAndAddStartTime(b.GetID(), ludo_board_constants.PLAYING, time.Now())
-
-		b.SetFirstTurn()
+		if err := b.store.UpdateStatusAndStartTime(b.GetID(), ludo_board_constants.PLAYING, time.Now()); err != nil {
+			log.Printf("Failed to store the start of board %s: %v", b.GetID(), err)
+		}
 
 		gameStartMessage := NewGameStartMessage(ludo_board_constants.GAME_START)
 
@@ -488,31 +688,504 @@ func (b *Board) startGameIfReady() {
 
 		b.broadCastMessage(gameStartMessage)
 
+		b.SetFirstTurn()
+
+		b.state = rules.NewState(b.buildQuadrantStates(), b.safePositions, b.GetFirstTurn(), b.ruleSet)
+		b.stateVersion++
+
 		// gs := &LudoGameService{}
 		// gs.CreateEmptyBoardInstances()
 
-		turnMessage := NewTurnMessage(ludo_board_constants.TURN, b.GetFirstTurn(), b.GetPawnsPositionsInTheBoard())
+		turnMessage := NewTurnMessage(ludo_board_constants.TURN, b.GetFirstTurn(), b.stateSync())
 		b.SetExpectedDiceRollMessage(b.GetFirstTurn(), 30*time.Second)
 		b.broadCastMessage(turnMessage)
+
+		if b.ruleSet.IsTimed() {
+			b.endsAt = time.Now().Add(time.Duration(b.ruleSet.TimeLimit) * time.Minute)
+			b.broadCastMessage(NewGameCountdownMessage(ludo_board_constants.GAME_COUNTDOWN, int(time.Until(b.endsAt).Seconds()), b.endsAt))
+			go b.runCountdown()
+		}
+	}
+}
+
+// GetPawnsPerQuadrant returns the number of pawns every quadrant plays with
+func (b *Board) GetPawnsPerQuadrant() int {
+	return b.pawnsPerQuadrant
+}
+
+// IsDrained reports whether the game is frozen because the server shuts down
+func (b *Board) IsDrained() bool {
+	return b.drained
+}
+
+// GetEndsAt returns when a timed board ends by score, zero for untimed boards
+func (b *Board) GetEndsAt() time.Time {
+	return b.endsAt
+}
+
+// runCountdown broadcasts the time left on a timed board and ends it by score once the time is up
+func (b *Board) runCountdown() {
+	ticker := time.NewTicker(ludo_board_constants.COUNTDOWN_INTERVAL)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		b.Lock()
+
+		if b.GetBoardStatus() != ludo_board_constants.PLAYING || b.drained {
+			b.Unlock()
+			return
+		}
+
+		remaining := time.Until(b.endsAt).Round(time.Second)
+		if remaining <= 0 {
+			if err := b.execute(rules.TimeUp{}); err != nil {
+				log.Printf("Failed to end timed board %s: %v", b.GetID(), err)
+			}
+			b.Unlock()
+			return
+		}
+
+		b.broadCastMessage(NewGameCountdownMessage(ludo_board_constants.GAME_COUNTDOWN, int(remaining.Seconds()), b.endsAt))
+
+		b.Unlock()
 	}
 }
 
 func (b *Board) calculateMovablePawns(steps int) []string {
-	var movablePawns []string
-	quadrant := b.GetQuadrant(b.currentTurn)
+	return b.state.MovablePawns(steps)
+}
+
+// buildQuadrantStates returns the occupied quadrants in turn order, as the rules see them
+func (b *Board) buildQuadrantStates() []rules.QuadrantState {
+	var quadrantStates []rules.QuadrantState
+
+	for _, q := range b.quadrants {
+		if q.GetPlayer() == nil {
+			continue
+		}
+
+		quadrantState := rules.QuadrantState{
+			Name: q.GetName(),
+			Path: q.GetPath(),
+			Team: b.GetTeam(q.GetName()),
+		}
+		for _, p := range q.GetPawns() {
+			quadrantState.PawnNames = append(quadrantState.PawnNames, p.GetName())
+			quadrantState.Pawns = append(quadrantState.Pawns, p.GetCurrentPathIndex())
+		}
+
+		quadrantStates = append(quadrantStates, quadrantState)
+	}
+
+	return quadrantStates
+}
 
-	for _, p := range quadrant.GetPawns() {
+// GetEngineState returns a copy of the rules state the engine can simulate on
+func (b *Board) GetEngineState() rules.State {
+	return b.state.Clone()
+}
+
+// execute runs the command through the rules and, once they accept it, mirrors the new state on the quadrants
+// and hands every event to the transport, store and wallet
+func (b *Board) execute(command rules.Command) error {
+	state, events, err := rules.Execute(b.state, command)
+	if err != nil {
+		return err
+	}
+
+	b.state = state
+	b.stateVersion++
+	b.syncPawns()
+
+	for _, event := range events {
+		b.handleEvent(event)
+	}
+
+	return nil
+}
+
+// syncPawns copies the pawn positions and the turn of the rules state to the quadrants of the board
+func (b *Board) syncPawns() {
+	for _, quadrantState := range b.state.Quadrants {
+		quadrantInstance := b.GetQuadrant(quadrantState.Name)
+
+		for i, pawnName := range quadrantState.PawnNames {
+			pawnInstance := quadrantInstance.GetPawnByName(pawnName)
+			if pawnInstance == nil {
+				continue
+			}
+
+			if quadrantState.Pawns[i] == -1 {
+				pawnInstance.SetPosition(nil)
+				pawnInstance.SetStatus(ludo_board_constants.PAWN_IDLE)
+				continue
+			}
+
+			position := quadrantState.Path[quadrantState.Pawns[i]]
+			pawnInstance.SetPosition(&position)
+			pawnInstance.SetStatus(ludo_board_constants.PAWN_PLAYING)
+		}
+	}
+
+	b.currentTurn = b.state.TurnQuadrant()
+	b.nextTurn = b.state.NextTurnQuadrant()
+}
+
+func (b *Board) handleEvent(event rules.Event) {
+	switch e := event.(type) {
+	case rules.DiceRolled:
+		b.SetDiceRolledValue(e.Value)
+
+		diceRolledMessage := dice.NewDiceRolledMessage(ludo_board_constants.BOARD_DICEROLLED, e.Value, e.Quadrant, e.MovablePawns, e.Forfeited, e.Values, e.MovablePawnsByDie)
+		b.SetExpectedMovePawnMessage(e.Quadrant, 30*time.Second, e.Value, b.state.RemainingDice())
+		b.broadCastMessage(diceRolledMessage)
+
+		if len(e.MovablePawns) > 0 {
+			b.sendHint(b.GetQuadrant(e.Quadrant), e.Value)
+		}
+	case rules.PawnMoved:
+		b.handlePawnMoved(e)
+	case rules.TurnStarted:
+		turnMessage := NewTurnMessage(ludo_board_constants.TURN, e.Quadrant, b.stateSync())
+		b.SetExpectedDiceRollMessage(e.Quadrant, 30*time.Second)
+		b.broadCastMessage(turnMessage)
+	case rules.GameWon:
+		b.handleGameWon(e)
+	}
+}
+
+func (b *Board) handlePawnMoved(e rules.PawnMoved) {
+	err := b.store.UpdatePawnMovement(b.id, e.Quadrant, e.Pawn, e.InitialPosition, e.FinalPosition, time.Now(), e.Steps)
+	if err != nil {
+		log.Printf("Failed to update pawn movement in database: %v", err)
+	}
+
+	// Rejected moves are only sent to the player who made them, see RejectPawnMove
+	movementDetails := pawn.NewPawnMovedMessage(map[string]interface{}{
+		"eventName":        ludo_board_constants.BOARD_PAWNMOVED,
+		"pawn":             e.Pawn,
+		"steps":            e.Steps,
+		"responseCode":     200,
+		"quadrant":         e.Quadrant,
+		"rolledBy":         e.RolledBy,
+		"initialPosition":  e.InitialPosition,
+		"finalPosition":    e.FinalPosition,
+		"initialIndex":     e.InitialIndex,
+		"finalIndex":       e.FinalIndex,
+		"isAtHome":         e.Finished,
+		"capturedPawns":    append([]string{}, e.CapturedPawns...),
+		"validationErrors": []pawn.ValidationError{},
+		"sync":             b.stateSync(),
+		"path":             b.crossedPositions(e),
+		"die":              e.Die,
+		"remainingDice":    e.RemainingDice,
+	})
+
+	// Broadcast the movement details
+	b.broadCastMessage(movementDetails)
+
+	if b.state.Phase == rules.PHASE_OVER {
+		// The Game.End message follows with the GameWon event
+		return
+	}
+
+	b.updateWinProbabilities()
+
+	if len(e.RemainingDice) > 0 {
+		// With two dice the other die moves before the turn completes
+		b.SetExpectedMovePawnMessage(e.RolledBy, 30*time.Second, b.state.Rolled[e.RemainingDice[0]], e.RemainingDice)
+		return
+	}
+
+	b.SetExpectedTurnCompletedMessage(e.RolledBy, 30*time.Second)
+}
+
+// rolledValues returns both dice of the last roll in two-dice games, nil otherwise
+func (b *Board) rolledValues() []int {
+	if !b.ruleSet.TwoDice {
+		return nil
+	}
+	return b.state.Rolled
+}
+
+// movablePawnsByDie returns the pawns each die of the last roll can move in two-dice games, nil otherwise
+func (b *Board) movablePawnsByDie() [][]string {
+	if !b.ruleSet.TwoDice {
+		return nil
+	}
+
+	var movablePawnsByDie [][]string
+	for i, value := range b.state.Rolled {
+		if b.state.Used[i] {
+			movablePawnsByDie = append(movablePawnsByDie, []string{})
+			continue
+		}
+		movablePawnsByDie = append(movablePawnsByDie, b.calculateMovablePawns(value))
+	}
+	return movablePawnsByDie
+}
+
+// crossedPositions returns the squares the pawn went through, the final one included, so clients can animate the move
+func (b *Board) crossedPositions(e rules.PawnMoved) []int {
+	path := b.state.Quadrants[b.state.QuadrantIndex(e.Quadrant)].Path
+
+	// An unlocked pawn only enters its start square
+	return append([]int{}, path[e.InitialIndex+1:e.FinalIndex+1]...)
+}
+
+// handleGameWon finishes the board and pays the winners, both partners in team games
+func (b *Board) handleGameWon(e rules.GameWon) {
+	var winners []string
+	for _, quadrant := range e.Quadrants {
+		if player := b.GetPlayerByQuadrant(quadrant); player != nil {
+			winners = append(winners, player.GetPlayerId())
+		}
+	}
+	// The pool is shared by the winning quadrants, the share of a player who has left is not paid out
+	winningAmount := b.getWinningAmount(len(e.Quadrants))
+
+	// Update game status to completed
+	err := b.store.UpdateStatusAndEndTime(b.GetID(), ludo_board_constants.FINISHED, time.Now())
+	if err != nil {
+		// log.Printf("Failed to update game status and end time in database: %v", err)
+	}
+
+	// Update game winner
+	err = b.store.UpdateWinner(b.GetID(), winners, winningAmount)
+
+	if err != nil {
+		log.Printf("Failed to update game winner in database: %v", err)
+	}
+
+	b.SetStatus(ludo_board_constants.FINISHED)
+
+	b.updateWinProbabilities()
+
+	endMessage := NewGameEndMessage(ludo_board_constants.GAME_END, winners, winningAmount, 200).
+		SetScoreboard(e.TimeUp, b.buildScoreboard(e.Scoreboard))
+
+	b.broadCastMessage(endMessage)
+
+	for _, winner := range winners {
+		CreateWinTransaction(b, winner, winningAmount)
+	}
+}
+
+// buildScoreboard names the quadrants and players of the rules scoreboard
+func (b *Board) buildScoreboard(scores []rules.Score) []QuadrantScore {
+	var scoreboard []QuadrantScore
+
+	for _, score := range scores {
+		quadrantName := b.state.Quadrants[score.Quadrant].Name
+
+		quadrantScore := QuadrantScore{
+			Rank:          score.Rank,
+			Quadrant:      quadrantName,
+			Progress:      score.Progress,
+			FinishedPawns: score.FinishedPawns,
+			Captures:      score.Captures,
+			Score:         score.Total,
+			TeamScore:     score.TeamTotal,
+		}
+		if player := b.GetPlayerByQuadrant(quadrantName); player != nil {
+			quadrantScore.PlayerId = player.GetPlayerId()
+		}
+
+		scoreboard = append(scoreboard, quadrantScore)
+	}
+
+	return scoreboard
+}
+
+// GetCurrentTurn returns the quadrant which has the current turn
+func (b *Board) GetCurrentTurn() string {
+	return b.currentTurn
+}
+
+// GetWinProbabilities returns the latest win probability estimate of the board
+func (b *Board) GetWinProbabilities() WinProbabilities {
+	b.winProbabilitiesMu.RLock()
+	defer b.winProbabilitiesMu.RUnlock()
+	return b.winProbabilities
+}
+
+// updateWinProbabilities counts the move just played and estimates the win probabilities of the new position
+// when the board has spectators
+func (b *Board) updateWinProbabilities() {
+	b.moveCount.Add(1)
+
+	if b.transport.HasSpectators(b.id) {
+		b.scheduleWinProbabilities()
+	}
+}
+
+// RequestWinProbabilities estimates the win probabilities of the current position unless the latest estimate is
+// up to date. The estimate runs in the background, GetWinProbabilities returns it once done.
+func (b *Board) RequestWinProbabilities() {
+	if b.GetWinProbabilities().MoveNumber < int(b.moveCount.Load()) {
+		b.scheduleWinProbabilities()
+	}
+}
+
+// scheduleWinProbabilities estimates the win probabilities after WIN_PROBABILITY_DELAY, unless an estimate is
+// already scheduled. Boards the engine does not support are not estimated.
+func (b *Board) scheduleWinProbabilities() {
+	if !engine.Supports(b.ruleSet) {
+		return
+	}
+
+	b.winProbabilitiesMu.Lock()
+	defer b.winProbabilitiesMu.Unlock()
+
+	if b.winProbabilitiesPending {
+		return
+	}
+	b.winProbabilitiesPending = true
+
+	time.AfterFunc(ludo_board_constants.WIN_PROBABILITY_DELAY, b.estimateWinProbabilities)
+}
+
+// estimateWinProbabilities estimates the win probabilities of the current position, then stores the estimate and
+// sends it to the spectators of the board. The estimate is dropped when a move was played while it ran.
+func (b *Board) estimateWinProbabilities() {
+	b.Lock()
+	b.winProbabilitiesMu.Lock()
+	b.winProbabilitiesPending = false
+	b.winProbabilitiesMu.Unlock()
+
+	moveNumber := int(b.moveCount.Load())
+	state := b.GetEngineState()
+
+	playerIds := make(map[string]string)
+	for _, q := range b.quadrants {
+		if q.GetPlayer() != nil {
+			playerIds[q.GetName()] = q.GetPlayer().GetPlayerId()
+		}
+	}
+	b.Unlock()
+
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	probabilities := engine.WinProbabilities(state, ludo_board_constants.WIN_PROBABILITY_ROLLOUTS, rnd)
+
+	winProbabilities := WinProbabilities{
+		MoveNumber: moveNumber,
+	}
+	for _, q := range state.Quadrants {
+		winProbabilities.Probabilities = append(winProbabilities.Probabilities, QuadrantWinProbability{
+			Quadrant:    q.Name,
+			PlayerId:    playerIds[q.Name],
+			Probability: probabilities[q.Name],
+		})
+	}
+
+	b.winProbabilitiesMu.Lock()
+	if moveNumber != int(b.moveCount.Load()) || moveNumber <= b.winProbabilities.MoveNumber {
+		// The board has moved on, the estimate of the later position replaces this one
+		b.winProbabilitiesMu.Unlock()
+		return
+	}
+	b.winProbabilities = winProbabilities
+	b.winProbabilitiesMu.Unlock()
+
+	b.transport.BroadcastToSpectators(NewSpectatorWinProbabilityMessage(ludo_board_constants.SPECTATOR_WIN_PROBABILITY, winProbabilities), b.id)
+}
+
+// sendHint sends the engine's evaluation of the movable pawns to the player who has to move
+func (b *Board) sendHint(quadrantInstance *quadrant.Quadrant, steps int) {
+	quadrantPlayer := quadrantInstance.GetPlayer()
+
+	if !b.hintsEnabled || quadrantPlayer == nil || quadrantPlayer.IsBotPlayer() || !engine.Supports(b.ruleSet) {
+		return
+	}
+
+	scoredMoves := engine.ScoreMoves(b.GetEngineState(), steps, ludo_board_constants.HINT_SEARCH_DEPTH)
+
+	best, ok := engine.Best(scoredMoves)
+	if !ok {
+		return
+	}
+
+	var moves []HintMove
+	for _, scoredMove := range scoredMoves {
+		moves = append(moves, HintMove{
+			Pawn:  scoredMove.PawnName,
+			Score: scoredMove.Score,
+		})
+	}
+
+	hintMessage := NewBoardHintMessage(ludo_board_constants.BOARD_HINT, quadrantInstance.GetName(), steps, best.PawnName, moves)
+	b.transport.Send(quadrantPlayer.GetPlayerId(), hintMessage, b.id)
+}
+
+// scheduleAutoPlay plays for the player if the expected message has not arrived once the auto play timer runs out
+func (b *Board) scheduleAutoPlay(expected *ExpectedMessage) {
+	if !b.autoPlay || expected == nil {
+		return
+	}
+
+	time.AfterFunc(time.Duration(b.autoPlayTimer)*time.Second, func() {
+		b.Lock()
+		defer b.Unlock()
+
+		if b.expectedMessage != expected || b.GetBoardStatus() != ludo_board_constants.PLAYING {
+			return
+		}
+
+		log.Printf("[AutoPlay] Playing %s for player %s on board %s", expected.EventName, expected.PlayerId, b.id)
+		b.autoPlayExpectedMessage(expected)
+	})
+}
+
+// autoPlayExpectedMessage performs the expected action on behalf of the player, moving the pawn the engine picks
+func (b *Board) autoPlayExpectedMessage(expected *ExpectedMessage) {
+	b.UnsetExpectedMessage()
+
+	switch expected.EventName {
+	case ludo_board_constants.BOARD_DICEROLL:
+		b.DiceRoll(expected.PlayerId)
+	case ludo_board_constants.BOARD_MOVEPAWN:
+		die, best, ok := b.bestMove(expected)
+		if !ok {
+			// The rules pass the turn right after a roll that moves nothing, so this is not expected
+			log.Printf("[AutoPlay] No movable pawn for quadrant %s", expected.Quadrant)
+			return
+		}
+
+		pawnMoveMessage := pawn.NewPawnMoveMessage(ludo_board_constants.BOARD_MOVEPAWN, expected.Quadrant, best.PawnName, b.state.Rolled[die], die)
+		b.autoPlaying = true
+		if err := b.MovePawn(expected.PlayerId, *pawnMoveMessage); err != nil {
+			log.Printf("[AutoPlay] Failed to move pawn %s: %v", best.PawnName, err)
+		}
+		b.autoPlaying = false
+	case ludo_board_constants.BOARD_TURN_COMPLETED:
+		b.TurnCompleted()
+	}
+}
 
-		log.Printf("Checking if pawn %s is a valid move", p.GetName())
+// bestMove returns the die and the move the engine picks among the dice the player can still move with.
+// Boards the engine does not support get a random move of the first die that moves a pawn.
+func (b *Board) bestMove(expected *ExpectedMessage) (int, engine.ScoredMove, bool) {
+	bestDie, best, found := 0, engine.ScoredMove{}, false
 
-		if p.IsValidMove(steps) {
-			log.Printf("pawn %s is a valid move with steps %d", p.GetName(), steps)
-			movablePawns = append(movablePawns, p.GetName())
+	if !engine.Supports(b.ruleSet) {
+		state := b.GetEngineState()
+		for _, die := range expected.RemainingDice {
+			if moves := state.LegalMoves(b.state.Rolled[die]); len(moves) > 0 {
+				move := moves[rand.Intn(len(moves))]
+				return die, engine.ScoredMove{Move: move, PawnName: state.PawnName(move)}, true
+			}
+		}
+		return bestDie, best, found
+	}
 
+	for _, die := range expected.RemainingDice {
+		move, ok := engine.Best(engine.ScoreMoves(b.GetEngineState(), b.state.Rolled[die], ludo_board_constants.AUTO_PLAY_SEARCH_DEPTH))
+		if ok && (!found || move.Score > best.Score) {
+			bestDie, best, found = die, move, true
 		}
 	}
 
-	return movablePawns
+	return bestDie, best, found
 }
 
 func (b *Board) BuildBoardJoinedMessage() *BoardJoinedMessage {
@@ -530,6 +1203,8 @@ func (b *Board) BuildBoardJoinedMessage() *BoardJoinedMessage {
 				Name: player.GetName(),
 			},
 			Quadrant: player.GetQuadrant(),
+			IsBot:    player.IsBotPlayer(),
+			Team:     b.GetTeam(player.GetQuadrant()),
 		})
 	}
 
@@ -599,21 +1274,99 @@ func (b *Board) GetAvailableQuadrants() []string {
 
 }
 
-// Set first turn
+// SetStartOrder sets how the quadrant playing first is picked when the game starts
+func (b *Board) SetStartOrder(startOrder ludo_board_constants.StartOrder) {
+	b.startOrder = startOrder
+}
+
+// GetStartOrder returns how the quadrant playing first is picked, a dice-off when none was set
+func (b *Board) GetStartOrder() ludo_board_constants.StartOrder {
+	if b.startOrder == "" {
+		return ludo_board_constants.START_DICE_OFF
+	}
+	return b.startOrder
+}
+
+// SetFirstTurn picks the quadrant playing first with the start order of the board and tells the players.
+// The turn then goes round the occupied quadrants in layout order from it.
 func (b *Board) SetFirstTurn() {
+	var occupied []*quadrant.Quadrant
 	for _, quadrant := range b.quadrants {
 		if quadrant.GetPlayer() != nil {
-			b.currentTurn = quadrant.GetName()
-			b.nextTurn = b.quadrants[(1)%len(b.quadrants)].GetName()
-			return
+			occupied = append(occupied, quadrant)
+		}
+	}
+	if len(occupied) == 0 {
+		return
+	}
+
+	first := 0
+	switch b.GetStartOrder() {
+	case ludo_board_constants.START_DICE_OFF:
+		first = b.diceOff(occupied)
+	case ludo_board_constants.START_RANDOM:
+		first = (&rng.RNG{}).Intn(len(occupied))
+	}
+
+	b.firstTurn = occupied[first].GetName()
+	b.currentTurn = b.firstTurn
+	b.nextTurn = occupied[(first+1)%len(occupied)].GetName()
+
+	b.broadCastMessage(NewGameFirstTurnMessage(ludo_board_constants.GAME_FIRST_TURN, b.firstTurn, string(b.GetStartOrder())))
+}
+
+// diceOff rolls a fair die for every contender and broadcasts each round, the highest rolls roll again until
+// a single one is left.
+// Returns:
+//   - int: index of the quadrant with the highest roll in the contenders
+func (b *Board) diceOff(contenders []*quadrant.Quadrant) int {
+	random := &rng.RNG{}
+
+	indexes := make([]int, len(contenders))
+	for i := range contenders {
+		indexes[i] = i
+	}
+
+	for round := 1; ; round++ {
+		rolls := make([]DiceOffRoll, len(indexes))
+		highest := 0
+		for i, index := range indexes {
+			rolls[i] = DiceOffRoll{
+				Quadrant: contenders[index].GetName(),
+				PlayerId: contenders[index].GetPlayer().GetPlayerId(),
+				Value:    random.Intn(6) + 1,
+			}
+			highest = max(highest, rolls[i].Value)
+		}
+
+		var tied []int
+		tiedNames := []string{}
+		for i, index := range indexes {
+			if rolls[i].Value == highest {
+				tied = append(tied, index)
+				tiedNames = append(tiedNames, rolls[i].Quadrant)
+			}
+		}
+		if len(tied) == 1 {
+			tiedNames = []string{}
+		}
+
+		b.broadCastMessage(NewGameDiceOffMessage(ludo_board_constants.GAME_DICE_OFF, round, rolls, tiedNames))
+
+		if len(tied) == 1 {
+			return tied[0]
 		}
+		indexes = tied
 	}
 }
 
-// GetFirstTurn returns the quadrant who will have the first turn will be the one with a player
+// GetFirstTurn returns the quadrant that played first, the first occupied one before the game starts
 // Returns:
-//   - *quadrant.Quadrant: returns the quadrant name e.b: QUADRANT_1
+//   - string: returns the quadrant name e.g: QUADRANT_1
 func (b *Board) GetFirstTurn() string {
+	if b.firstTurn != "" {
+		return b.firstTurn
+	}
 	for _, quadrant := range b.quadrants {
 		if quadrant.GetPlayer() != nil {
 			return quadrant.GetName()
@@ -644,65 +1397,18 @@ func (b *Board) GetQuadrant(quadrantName string) *quadrant.Quadrant {
 	return nil
 }
 
-// NextTurn manages the turn sequence between players based on the dice roll
-// If a player rolls a 6, captures a pawn, or reaches the last position, they get another turn; otherwise, turn passes to the next player
-// Parameters:
-//   - diceRoll (int): The number rolled on the dice (1-6)
-//   - captured (bool): Indicates if a pawn was captured
-//   - reachedLastPosition (bool): Indicates if a pawn reached the last position
-func (b *Board) NextTurn(diceRoll int, captured bool, reachedLastPosition bool, sendMessage bool) {
-	if len(b.quadrants) == 0 {
-		return
-	}
-
-	// If the dice roll is 6, a pawn was captured, or a pawn reached the last position, the current player retains their turn
-	if diceRoll == 6 || captured || reachedLastPosition {
-		// log.Printf("player.Player in quadrant %s gets another turn (diceRoll: %d, captured: %v, reachedLastPosition: %v)", b.currentTurn, diceRoll, captured, reachedLastPosition)
-		if sendMessage {
-			// Broadcast the next turn message
-			turnMessage := NewTurnMessage(ludo_board_constants.TURN, b.currentTurn, b.GetPawnsPositionsInTheBoard())
-			b.SetExpectedDiceRollMessage(b.currentTurn, 30*time.Second)
-			b.broadCastMessage(turnMessage)
-		}
+// TurnCompleted tells the rules the player who moved last is done, which starts the turn of the quadrant having it
+func (b *Board) TurnCompleted() {
+	if len(b.state.Quadrants) == 0 {
 		return
 	}
 
-	// Move to the next quadrant in the list
-	currentIndex := -1
-	for i, quadrant := range b.quadrants {
-		if quadrant.GetName() == b.currentTurn {
-			currentIndex = i
-			break
-		}
-	}
-
-	if currentIndex != -1 {
-		for i := 1; i < len(b.quadrants); i++ {
-			nextIndex := (currentIndex + i) % len(b.quadrants)
-			if b.quadrants[nextIndex].GetPlayer() != nil {
-				b.currentTurn = b.quadrants[nextIndex].GetName()
-				b.nextTurn = b.quadrants[(nextIndex+1)%len(b.quadrants)].GetName()
-				log.Printf("Next turn is for quadrant %s", b.currentTurn)
-				break
-			}
-		}
-	}
-
-	if sendMessage {
-		// Broadcast the next turn message
-		turnMessage := NewTurnMessage(ludo_board_constants.TURN, b.currentTurn, b.GetPawnsPositionsInTheBoard())
-		b.SetExpectedDiceRollMessage(b.currentTurn, 30*time.Second)
-		b.broadCastMessage(turnMessage)
+	err := b.execute(rules.CompleteTurn{Quadrant: b.state.Quadrants[b.state.Mover].Name})
+	if err != nil {
+		log.Printf("Failed to complete the turn on board %s: %v", b.id, err)
 	}
 }
 
-// TurnCompleted broadcasts a message to indicate that the current player's turn has been completed
-func (b *Board) TurnCompleted() {
-	turnMessage := NewTurnMessage(ludo_board_constants.TURN, b.currentTurn, b.GetPawnsPositionsInTheBoard())
-	b.SetExpectedDiceRollMessage(b.currentTurn, 30*time.Second)
-	b.broadCastMessage(turnMessage)
-}
-
 // Get all the pawns' positions in the board
 func (b *Board) GetPawnsPositionsInTheBoard() []pawn.PawnPositions {
 	var allPawnPositions []pawn.PawnPositions
@@ -722,6 +1428,10 @@ func (b *Board) GetPawnsPositionsInTheBoard() []pawn.PawnPositions {
 			pawnPositions.AddPosition(p.GetName(), positionValue)
 		}
 
+		if quadrantIndex := b.state.QuadrantIndex(quadrant.GetName()); quadrantIndex != -1 {
+			pawnPositions.Blockades = b.state.Blockades(quadrantIndex)
+		}
+
 		allPawnPositions = append(allPawnPositions, pawnPositions)
 	}
 
@@ -729,150 +1439,204 @@ func (b *Board) GetPawnsPositionsInTheBoard() []pawn.PawnPositions {
 	return allPawnPositions
 }
 
-// MovePawn executes a pawn movement for a player by the specified number of steps
+// GetStateVersion returns the version of the game state, incremented on every change
+func (b *Board) GetStateVersion() int {
+	return b.stateVersion
+}
+
+// stateSync returns the version and checksum of the state with the pawns moved since the last message sent to the
+// clients, and records the positions as sent
+func (b *Board) stateSync() pawn.StateSync {
+	positions := b.GetPawnsPositionsInTheBoard()
+	sync := pawn.StateSync{
+		Version:  b.stateVersion,
+		Checksum: pawn.StateChecksum(positions),
+		Changes:  pawn.DiffPositions(b.syncedPositions, positions),
+	}
+	b.syncedPositions = positions
+	return sync
+}
+
+// Snapshot returns the full state of the board, clients resync from it
+func (b *Board) Snapshot() StateSnapshot {
+	positions := b.GetPawnsPositionsInTheBoard()
+	return StateSnapshot{
+		Version:   b.stateVersion,
+		Checksum:  pawn.StateChecksum(positions),
+		Turn:      b.currentTurn,
+		Positions: positions,
+	}
+}
+
+// SendSnapshot sends the full state of the board to a player whose checksum does not match, echoing the id of the request
+func (b *Board) SendSnapshot(playerId string, requestId string) error {
+	if b.GetPlayerByPlayerId(playerId) == nil {
+		return common.NewSocketError(common.NOT_FOUND, fmt.Sprintf("player %s is not on board %s", playerId, b.id))
+	}
+
+	b.transport.Send(playerId, NewBoardStateSnapshotMessage(ludo_board_constants.BOARD_STATE_SNAPSHOT, b.Snapshot(), requestId), b.id)
+	return nil
+}
+
+// MovePawn executes a pawn movement for a player by the specified number of steps.
+// The server checks the move against the rules, rejected moves are sent back to the sender only and
+// the turn waits for another move.
 // Parameters:
+//   - playerId (string): The ID of the player sending the move
 //   - pawnMoveMessage (messages.PawnMoveMessage): The message containing the details of the pawn move
 //
 // Returns:
 //   - error: Error if the move is invalid, nil if successful
-func (b *Board) MovePawn(pawnMoveMessage pawn.PawnMoveMessage) error {
+func (b *Board) MovePawn(playerId string, pawnMoveMessage pawn.PawnMoveMessage) error {
 	quadrantName := pawnMoveMessage.GetQuadrant()
-	pawnName := pawnMoveMessage.GetPawn()
-	steps := pawnMoveMessage.GetSteps()
-	if b.expectedMessage != nil {
-		if b.expectedMessage.Steps != steps {
-			err := fmt.Errorf("invalid steps %d, expected %d on quadrant %s by player %s", steps, b.expectedMessage.Steps, quadrantName, b.GetPlayerByQuadrant(quadrantName).GetPlayerId())
-			// log.Println(err)
-			return err
-		}
+
+	sender := b.GetPlayerByPlayerId(playerId)
+	if sender == nil || !b.canMoveFor(sender.GetQuadrant(), quadrantName) {
+		return b.RejectPawnMove(playerId, pawnMoveMessage, ludo_board_constants.MOVE_NOT_YOUR_QUADRANT, fmt.Errorf("quadrant %s does not belong to the player", quadrantName))
 	}
 
-	// log.Printf("Attempting to move pawn %s in quadrant %s by %d steps", pawnName, quadrantName, steps)
+	previous := b.state
 
-	// Get the player's quadrant
-	quadrantInstance := b.GetQuadrant(quadrantName)
+	err := b.execute(rules.MovePawn{
+		Quadrant: quadrantName,
+		Pawn:     pawnMoveMessage.GetPawn(),
+		Steps:    pawnMoveMessage.GetSteps(),
+		Die:      pawnMoveMessage.GetDie(),
+	})
 
-	if quadrantInstance == nil {
-		err := fmt.Errorf("no quadrant found with name %s", quadrantName)
-		// log.Println(err)
-		return err
+	if err != nil {
+		return b.RejectPawnMove(playerId, pawnMoveMessage, moveErrorCode(err), err)
 	}
 
-	// Get the player from the quadrant
-	player := quadrantInstance.GetPlayer()
+	b.recordMove(sender, previous, pawnMoveMessage)
 
-	if player == nil {
-		err := fmt.Errorf("no player found in quadrant %s", quadrantName)
-		// log.Println(err)
-		return err
-	}
+	return nil
+}
 
-	// Get the pawn by name
-	pawnInstance := quadrantInstance.GetPawnByName(pawnName)
+// recordMove stores the move with the captures the roll offered and how fast the player answered, so the
+// anti-cheat analysis can look for declined captures and inhuman reaction times once the board is over
+func (b *Board) recordMove(sender *player.Player, previous rules.State, pawnMoveMessage pawn.PawnMoveMessage) {
+	record := MoveRecordSchema{
+		PlayerId:            sender.GetPlayerId(),
+		Quadrant:            pawnMoveMessage.GetQuadrant(),
+		Pawn:                pawnMoveMessage.GetPawn(),
+		Steps:               pawnMoveMessage.GetSteps(),
+		CapturedQuadrants:   []string{},
+		CapturableQuadrants: []string{},
+		AutoPlayed:          b.autoPlaying || sender.IsBotPlayer(),
+		Timestamp:           time.Now(),
+	}
 
-	if pawnInstance == nil {
-		err := fmt.Errorf("pawn %s not found for player %s", pawnName, player.ID)
-		// log.Println(err)
-		return err
+	if expected := b.lastExpectedMessage; expected != nil && expected.EventName == ludo_board_constants.BOARD_MOVEPAWN {
+		record.ReactionMs = time.Since(expected.TStamp).Milliseconds()
 	}
 
-	// Move the pawn
-	moveResult, err := pawnInstance.MovePawn(steps, quadrantName)
+	mover := previous.Quadrants[previous.MovingQuadrant()]
+	for _, move := range previous.LegalMoves(pawnMoveMessage.GetSteps()) {
+		_, outcome := previous.Apply(move)
 
-	if err != nil {
-		// log.Printf("Failed to move pawn %s: %v", pawnName, err)
-		return err
+		for _, capturedPawn := range outcome.CapturedPawns {
+			owner := previous.PawnQuadrant(capturedPawn)
+			if !slices.Contains(record.CapturableQuadrants, owner) {
+				record.CapturableQuadrants = append(record.CapturableQuadrants, owner)
+			}
+			if mover.PawnNames[move.Pawn] == pawnMoveMessage.GetPawn() && !slices.Contains(record.CapturedQuadrants, owner) {
+				record.CapturedQuadrants = append(record.CapturedQuadrants, owner)
+			}
+		}
 	}
 
-	// Handle capturing of opponent's pawn
-	capturedPawns := b.capturePawnIfPresent(pawnInstance, quadrantInstance)
-
-	// Update the pawn movement in the database
-	boardDAO := NewBoardDAO()
+	if err := b.store.AddMoveRecord(b.id, record); err != nil {
+		log.Printf("Failed to add move record in database: %v", err)
+	}
+}
 
-	err = boardDAO.UpdateBoardPawnMovement(b.id, quadrantName, pawnName, moveResult.InitialPosition, moveResult.FinalPosition, time.Now(), steps)
+// canMoveFor tells if the player of a quadrant can move the pawns of another one, which is only the case
+// of a finished partner moving for the quadrant having the turn
+func (b *Board) canMoveFor(senderQuadrant string, quadrantName string) bool {
+	if senderQuadrant == quadrantName {
+		return true
+	}
+	if len(b.state.Quadrants) == 0 {
+		return false
+	}
+	return quadrantName == b.state.TurnQuadrant() && senderQuadrant == b.state.Quadrants[b.state.MovingQuadrant()].Name
+}
 
+// RejectPawnMove sends the reason a pawn move was rejected to the player who sent it and records the violation
+// for anti-cheat review. When the roll is still waiting for a move of the player, it keeps waiting until the
+// original deadline, so rejected moves cannot stall the board.
+// Returns:
+//   - error: The reason of the rejection, an invalid turn when the player had not to move, else an invalid pawn
+func (b *Board) RejectPawnMove(playerId string, pawnMoveMessage pawn.PawnMoveMessage, code ludo_board_constants.MoveErrorCode, reason error) error {
+	if b.violations == nil {
+		b.violations = map[string]int{}
+	}
+	b.violations[playerId]++
+
+	err := b.store.AddViolation(b.id, ViolationSchema{
+		PlayerId:  playerId,
+		Quadrant:  pawnMoveMessage.GetQuadrant(),
+		Pawn:      pawnMoveMessage.GetPawn(),
+		Steps:     pawnMoveMessage.GetSteps(),
+		Code:      string(code),
+		Timestamp: time.Now(),
+	})
 	if err != nil {
-		// log.Printf("Failed to update pawn movement in database: %v", err)
-		return err
+		log.Printf("Failed to add violation in database: %v", err)
 	}
 
-	// Check if all pawns of the player are finished
-	allFinished := true
-
-	for _, p := range quadrantInstance.GetPawns() {
-		if !p.IsAtFinish() {
-			allFinished = false
-			break
-		}
+	last := b.lastExpectedMessage
+	if b.expectedMessage == nil && last != nil && last.EventName == ludo_board_constants.BOARD_MOVEPAWN && last.PlayerId == playerId && b.state.Phase == rules.PHASE_MOVE {
+		b.expectedMessage = last
 	}
 
-	movementDetails := pawn.NewPawnMovedMessage(map[string]interface{}{
-		"eventName": ludo_board_constants.BOARD_PAWNMOVED,
-		"pawn":      pawnInstance.GetName(),
-		"steps":     steps,
-		"responseCode": func() int {
-			if moveResult.ValidationErrors[0].Message != "" {
-				return 400
-			} else {
-				return 200
+	movablePawns := []string{}
+	if expected := b.expectedMessage; expected != nil && expected.EventName == ludo_board_constants.BOARD_MOVEPAWN && expected.PlayerId == playerId {
+		for _, die := range expected.RemainingDice {
+			for _, pawnName := range b.calculateMovablePawns(b.state.Rolled[die]) {
+				if !slices.Contains(movablePawns, pawnName) {
+					movablePawns = append(movablePawns, pawnName)
+				}
 			}
-		}(),
-		"quadrant":         quadrantInstance.GetName(),
-		"initialPosition":  moveResult.InitialPosition,
-		"finalPosition":    moveResult.FinalPosition,
-		"initialIndex":     moveResult.InitialIndex,
-		"finalIndex":       moveResult.FinalIndex,
-		"isAtHome":         moveResult.IsAtHome,
-		"capturedPawns":    capturedPawns,
-		"validationErrors": moveResult.ValidationErrors,
-		"positions":        b.GetPawnsPositionsInTheBoard(),
-	})
-
-	// Broadcast the movement details
-	b.broadCastMessage(movementDetails)
-
-	// Emit Game.Winner message if all pawns are finished
-	if allFinished {
-		// log.Printf("Player %s has finished all pawns. Emitting Game.Winner message.", player.GetPlayerId())
-		// Update game status to completed
-		err = UpdateBoardStatusAndAddEndTimeInDB(b.GetID(), ludo_board_constants.FINISHED, time.Now())
-		if err != nil {
-			// log.Printf("Failed to update game status and end time in database: %v", err)
-		}
-
-		// Update game winner
-		err = UpdateGameWinnerInDB(b.GetID(), player.GetPlayerId(), b.getWinningAmount())
-
-		if err != nil {
-			log.Printf("Failed to update game winner in database: %v", err)
 		}
+	}
 
-		b.SetStatus(ludo_board_constants.FINISHED)
-
-		endMessage := NewGameEndMessage(ludo_board_constants.GAME_END, player.GetPlayerId(), b.getWinningAmount(), 200)
-
-		b.broadCastMessage(endMessage)
-
-		CreateWinTransaction(b, player.GetPlayerId(), b.getWinningAmount())
-
-		// gameEndMessage := NewGameEndMessage(ludo_board_constants.GAME_END)
+	rejectedMessage := pawn.NewPawnMoveRejectedMessage(ludo_board_constants.BOARD_MOVE_REJECTED, pawnMoveMessage.GetQuadrant(), pawnMoveMessage.GetPawn(), pawnMoveMessage.GetSteps(), string(code), reason.Error(), movablePawns, b.violations[playerId])
+	b.transport.Send(playerId, rejectedMessage, b.id)
 
-		b.broadCastMessage(endMessage)
+	log.Printf("Rejected move of pawn %s of quadrant %s by player %s on board %s: %s (%d violations)", pawnMoveMessage.GetPawn(), pawnMoveMessage.GetQuadrant(), playerId, b.id, code, b.violations[playerId])
 
-		return nil
+	errorCode := common.INVALID_PAWN
+	if code == ludo_board_constants.MOVE_NOT_YOUR_TURN || code == ludo_board_constants.MOVE_NOT_EXPECTED {
+		errorCode = common.INVALID_TURN
 	}
 
-	// log.Printf("Broadcasted movement details for pawn %s", pawnName)
-
-	// Handle next turn
-	b.NextTurn(steps, len(capturedPawns) > 0, moveResult.IsAtHome, false)
-
-	b.SetExpectedTurnCompletedMessage(quadrantInstance.GetName(), 30*time.Second)
+	return common.NewSocketError(errorCode, fmt.Sprintf("pawn %s of quadrant %s cannot move %d steps for player %s: %v", pawnMoveMessage.GetPawn(), pawnMoveMessage.GetQuadrant(), pawnMoveMessage.GetSteps(), playerId, reason))
+}
 
-	// log.Printf("Broadcasted turn message for quadrant %s", b.currentTurn)
+// GetViolations returns the number of pawn moves of the player the server rejected on the board
+func (b *Board) GetViolations(playerId string) int {
+	return b.violations[playerId]
+}
 
-	return nil
+// moveErrorCode returns the error code sent to the player for an error of the rules
+func moveErrorCode(err error) ludo_board_constants.MoveErrorCode {
+	switch {
+	case errors.Is(err, rules.ErrNotYourTurn):
+		return ludo_board_constants.MOVE_NOT_YOUR_TURN
+	case errors.Is(err, rules.ErrInvalidSteps):
+		return ludo_board_constants.MOVE_INVALID_STEPS
+	case errors.Is(err, rules.ErrDieUsed):
+		return ludo_board_constants.MOVE_DIE_USED
+	case errors.Is(err, rules.ErrUnknownPawn):
+		return ludo_board_constants.MOVE_UNKNOWN_PAWN
+	case errors.Is(err, rules.ErrIllegalMove):
+		return ludo_board_constants.MOVE_PAWN_NOT_MOVABLE
+	case errors.Is(err, rules.ErrGameOver):
+		return ludo_board_constants.MOVE_GAME_OVER
+	}
+	return ludo_board_constants.MOVE_NOT_EXPECTED
 }
 
 // IsGameOver checks if any player has won the game
@@ -887,63 +1651,6 @@ func (b *Board) IsGameOver() bool {
 	return false
 }
 
-// capturePawnIfPresent checks if any opponent's pawn is at the same position after the move and captures it
-// Parameters:
-//   - pawn (*pawn.Pawn): The pawn that just moved
-//   - player (*player.Player): The player who moved the pawn
-//
-// Returns:
-//   - []string: List of captured pawn names
-func (b *Board) capturePawnIfPresent(pawn *pawn.Pawn, quadrant *quadrant.Quadrant) []string {
-	capturedPawns := []string{}
-	// log.Printf("Checking for captures by pawn %s of quadrant %s at position %v", pawn.GetName(), quadrant.GetName(), pawn.GetPosition())
-	for _, otherQuadrant := range b.quadrants {
-		if otherQuadrant.GetName() == quadrant.GetName() {
-			continue
-		}
-
-		if otherQuadrant == nil {
-			// log.Printf("Opponent quadrant %s is nil", otherQuadrant.GetName())
-			continue
-		}
-		for _, otherQuadrantPawn := range otherQuadrant.GetPawns() {
-
-			if otherQuadrantPawn == nil {
-				// log.Printf("Opponent pawn is nil for quadrant %s", otherQuadrant.GetName())
-				continue
-			}
-
-			// log.Printf("Checking opponent pawn %s of quadrant %s at position %v", otherQuadrantPawn.GetName(), otherQuadrant.GetName(), otherQuadrantPawn.GetPosition())
-
-			if otherQuadrantPawn.GetPosition() != nil && pawn.GetPosition() != nil && *otherQuadrantPawn.GetPosition() == *pawn.GetPosition() && !otherQuadrantPawn.IsIdle() {
-				// Check if the position is a safe zone
-				isSafeZone := false
-				for _, safePos := range b.safePositions {
-					if safePos == *pawn.GetPosition() {
-						isSafeZone = true
-						break
-					}
-				}
-				// Capture the opponent's pawn if not in a safe zone
-				if !isSafeZone {
-					// log.Printf("Capturing pawn %s of player %s at position %d", otherQuadrantPawn.GetName(), otherQuadrant.GetName(), *otherQuadrantPawn.GetPosition())
-					otherQuadrantPawn.SetPosition(nil)
-					otherQuadrantPawn.SetStatus(ludo_board_constants.PAWN_IDLE)
-					otherQuadrantPawn.SetPosition(nil) // Reset the position to nil
-					capturedPawns = append(capturedPawns, otherQuadrantPawn.GetName())
-					// log.Printf("player.Player %s's pawn %s captured player %s's pawn %s!", quadrant.GetName(), pawn.GetName(), otherQuadrant.GetName(), otherQuadrantPawn.GetName())
-				} else {
-					// log.Printf("pawn.Pawn %s of player %s is in a safe zone at position %d and cannot be captured", otherQuadrantPawn.GetName(), otherQuadrant.GetName(), *otherQuadrantPawn.GetPosition())
-				}
-			} else {
-				// log.Printf("No capture: Opponent pawn %s of player %s is at position %v, current pawn %s of player %s is at position %v",
-				// 	otherQuadrantPawn.GetName(), otherQuadrant.GetName(), otherQuadrantPawn.GetPosition(), pawn.GetName(), quadrant.GetName(), pawn.GetPosition())
-			}
-		}
-	}
-	return capturedPawns
-}
-
 // DiceRoll handles the dice roll for a player
 // Parameters:
 //   - playerId (string): The ID of the player rolling the dice
@@ -960,62 +1667,25 @@ func (b *Board) DiceRoll(playerId string) {
 		return
 	}
 
-	// Check if the player has any pawns unlocked
-	hasUnlockedPawns := false
-
-	for _, pawn := range quadrantInstance.GetPawns() {
-		if !pawn.IsIdle() {
-			hasUnlockedPawns = true
-			break
-		}
-	}
-
-	diceInstance := &dice.Dice{}
-	diceValue := diceInstance.Roll()
+	diceValue := b.rollDie()
 	// log.Printf("Dice value: %d", diceValue)
-	time.Sleep(300 * time.Millisecond)
-
-	b.SetDiceRolledValue(diceValue)
-
-	movablePawns := b.calculateMovablePawns(diceValue)
 
-	// Broadcast the dice rolled message once
-	diceRolledMessage := dice.NewDiceRolledMessage("Board.DiceRolled", diceValue, quadrantInstance.GetName(), movablePawns)
-	b.SetExpectedMovePawnMessage(quadrantInstance.GetName(), 30*time.Second, diceValue)
-	b.broadCastMessage(diceRolledMessage)
-	// log.Printf("Broadcasted dice rolled message")
-
-	// If the player has no pawns unlocked and the dice value is not 6, pass the turn
-	if !hasUnlockedPawns && diceValue != 6 {
-		// log.Printf("player.Player %s has no unlocked pawns and rolled a %d. Passing turn to the next player.", playerId, diceValue)
-		b.NextTurn(diceValue, false, false, true)
-		return
-	}
-
-	// Check if any pawn can move the rolled dice value
-	canMove := false
-	for _, pawn := range quadrantInstance.GetPawns() {
-		if (pawn.IsIdle() && diceValue == 6) || (!pawn.IsIdle() && pawn.IsValidMove(diceValue)) {
-			canMove = true
-			break
-		}
+	secondValue := 0
+	if b.ruleSet.TwoDice {
+		secondValue = b.rollDie()
 	}
+	time.Sleep(300 * time.Millisecond)
 
-	// If no pawn can move the rolled dice value, pass the turn
-	if !canMove {
-		// log.Printf("player.Player %s has no pawns that can move %d steps. Passing turn to the next player.", playerId, diceValue)
-		b.NextTurn(diceValue, false, false, true)
-		return
-	}
+	// The rules broadcast the roll, and pass the turn when no pawn can move
+	err := b.execute(rules.RollDice{
+		Quadrant: quadrantInstance.GetName(),
+		Value:    diceValue,
+		Second:   secondValue,
+	})
 
-	// If the dice roll is 6, the player gets another turn
-	if diceValue == 6 {
-		// log.Printf("player.Player %s rolled a 6 and gets another turn.", playerId)
-		return
+	if err != nil {
+		log.Printf("Failed to roll the dice for player %s on board %s: %v", playerId, b.id, err)
 	}
-
-	// If the dice roll is not 6 and the player has pawns that can move, no turn message is broadcasted
-	// log.Printf("player.Player %s has pawns that can move %d steps.", playerId, diceValue)
 }
 
 func (b *Board) UpdateQuadrantSelection(playerId string) {
@@ -1067,7 +1737,7 @@ func (b *Board) SendQuadrantSelectionMessage() {
 	)
 
 	b.broadCastMessage(boardSelectingQuadrantMessage)
-	socket.SendMessage(playerToSend.ID, quadrantSelectionPromptMessage, b.id)
+	b.transport.Send(playerToSend.ID, quadrantSelectionPromptMessage, b.id)
 }
 
 func (b *Board) SendGameInitializeMessage(playerId string) {
@@ -1082,6 +1752,7 @@ func (b *Board) SendGameInitializeMessage(playerId string) {
 			Color: quadrant.GetColor(),
 			Pawns: quadrant.GetPawnNames(),
 			Path:  quadrant.GetPath(),
+			Team:  b.GetTeam(quadrant.GetName()),
 		})
 	}
 
@@ -1097,9 +1768,14 @@ func (b *Board) SendGameInitializeMessage(playerId string) {
 		}
 	}
 
-	gameInitializeMessage := NewGameInitializeMessage(ludo_board_constants.GAME_INITIALIZE, safePositions, quadrants, b.autoPlay, b.playersRequiredToStartGame, b.ticketAmount, playerSelectingTheQuadrant, b.autoPlayTimer)
+	gameInitializeMessage := NewGameInitializeMessage(ludo_board_constants.GAME_INITIALIZE, safePositions, quadrants, b.autoPlay, b.playersRequiredToStartGame, b.ticketAmount, playerSelectingTheQuadrant, b.autoPlayTimer, b.ruleSet, Grid{
+		Layout: b.geometry.Layout,
+		Rows:   b.geometry.Rows,
+		Cols:   b.geometry.Cols,
+		Cells:  b.geometry.Cells(),
+	})
 
-	socket.SendMessage(playerId, gameInitializeMessage, b.id)
+	b.transport.Send(playerId, gameInitializeMessage, b.id)
 
 }
 
@@ -1135,14 +1811,16 @@ func (b *Board) SendBoardWaitingPlayersMessage(player *player.Player) {
 	boardWaitingPlayersMessage := NewBoardWaitingPlayersMessage(ludo_board_constants.BOARD_WAITING_PLAYERS, waitingPlayers,
 		newPlayer, playerSelectingQuadrant)
 
-	socket.BroadcastMessage(boardWaitingPlayersMessage, b.GetID())
+	b.broadCastMessage(boardWaitingPlayersMessage)
 }
 
-func (b *Board) getWinningAmount() int {
+// getWinningAmount returns the amount paid to each of the winners
+func (b *Board) getWinningAmount(winners int) int {
 	// log.Printf("Calculating winning amount with ticket amount: %d, players required: %d, rake amount: %d, rake amount type: %s",
 	// b.ticketAmount, b.playersRequiredToStartGame, b.rakeAmount, b.rakeAmountType)
 
-	poolAmount := b.ticketAmount * b.playersRequiredToStartGame
+	// Bots never pay a ticket, so only the seats taken by humans fund the pool
+	poolAmount := b.ticketAmount * (b.playersRequiredToStartGame - b.GetBotCount())
 
 	// winningAmount := b.ticketAmount * b.playersRequiredToStartGame
 	// log.Printf("Total prize pool before rake: %d", poolAmount)
@@ -1158,21 +1836,24 @@ func (b *Board) getWinningAmount() int {
 
 	// log.Printf("Final winning amount after deducting rake: %d", winningAmount)
 
-	return winningAmount
+	return winningAmount / winners
 }
 
 func (b *Board) handleAllDisconnection() error {
 	// log.Printf("All players disconnected, discarding board %s", b.GetID())
 
-	for _, p := range b.GetPlayers() {
-		b.CreateRefundTransaction(p.GetPlayerId(), float64(b.GetTicketAmount()))
+	// RemovePlayer changes the slice of the players, so it is copied first
+	for _, p := range append([]*player.Player(nil), b.GetPlayers()...) {
+		if !p.IsBotPlayer() {
+			CreateRefundTransaction(b, p.GetPlayerId(), float64(b.GetTicketAmount()))
+		}
 		b.GetQuadrant(p.GetQuadrant()).RemovePlayer()
 		b.RemovePlayer(p.ID)
 	}
 
 	b.SetStatus(ludo_board_constants.DISCARDED)
 
-	err := UpdateBoardStatusAndAddEndTimeInDB(b.GetID(), ludo_board_constants.DISCARDED, time.Now())
+	err := b.store.UpdateStatusAndEndTime(b.GetID(), ludo_board_constants.DISCARDED, time.Now())
 
 	if err != nil {
 		// log.Printf("Failed to update game status and end time in database: %v", err)
@@ -1181,17 +1862,55 @@ func (b *Board) handleAllDisconnection() error {
 	return nil
 }
 
+// Drain closes a WAITING board to new players when the server shuts down. Its game would never start, so the
+// players who paid their ticket are refunded and unseated. A board being played is frozen first, its auto-play
+// and countdown stop and no move is accepted, then its state is stored. Restoring the board from the stored
+// state is out of scope, it records where the game stopped.
+func (b *Board) Drain() error {
+	switch b.GetBoardStatus() {
+	case ludo_board_constants.WAITING:
+		// RemovePlayer changes the slice of the players, so it is copied first
+		for _, p := range append([]*player.Player(nil), b.GetPlayers()...) {
+			if p.HasSelectedQuadrant() {
+				CreateRefundTransaction(b, p.GetPlayerId(), float64(b.GetTicketAmount()))
+			}
+			b.RemovePlayer(p.ID)
+		}
+
+		b.SetStatus(ludo_board_constants.UNAVAILABLE)
+
+		return b.store.UpdateStatusAndEndTime(b.GetID(), ludo_board_constants.UNAVAILABLE, time.Now())
+	case ludo_board_constants.PLAYING:
+		b.drained = true
+		b.UnsetExpectedMessage()
+
+		snapshot := b.Snapshot()
+
+		return b.store.SaveState(b.GetID(), StateSchema{
+			Version:   snapshot.Version,
+			Checksum:  snapshot.Checksum,
+			Turn:      snapshot.Turn,
+			Positions: snapshot.Positions,
+			SavedAt:   time.Now(),
+		})
+	}
+
+	return nil
+}
+
 func (b *Board) handleAllDisconnectedExceptOne(remainingPlayerId string) error {
 
-	remainingPlayer := b.GetPlayerByPlayerId(remainingPlayerId)
+	// In team games the partner of the last connected player wins too
+	winners := b.getTeamPlayerIds(remainingPlayerId)
+	winningAmount := b.getWinningAmount(len(winners))
 
-	err := UpdateBoardStatusAndAddEndTimeInDB(b.GetID(), ludo_board_constants.FINISHED, time.Now())
+	err := b.store.UpdateStatusAndEndTime(b.GetID(), ludo_board_constants.FINISHED, time.Now())
 	if err != nil {
 		// log.Printf("Failed to update game status and end time in database: %v", err)
 	}
 
 	// Update game winner
-	err = UpdateGameWinnerInDB(b.GetID(), remainingPlayer.GetPlayerId(), b.getWinningAmount())
+	err = b.store.UpdateWinner(b.GetID(), winners, winningAmount)
 
 	if err != nil {
 		// log.Printf("Failed to update game winner in database: %v", err)
@@ -1199,13 +1918,16 @@ func (b *Board) handleAllDisconnectedExceptOne(remainingPlayerId string) error {
 
 	b.SetStatus(ludo_board_constants.FINISHED)
 
-	CreateWinTransaction(b, remainingPlayerId, b.getWinningAmount())
+	for _, winner := range winners {
+		CreateWinTransaction(b, winner, winningAmount)
+	}
 
-	endMessage := NewGameEndMessage(ludo_board_constants.GAME_END, remainingPlayer.GetPlayerId(), b.getWinningAmount(), 200)
+	endMessage := NewGameEndMessage(ludo_board_constants.GAME_END, winners, winningAmount, 200)
 
-	socket.BroadcastMessage(endMessage, b.GetID())
+	b.broadCastMessage(endMessage)
 
-	for _, p := range b.GetPlayers() {
+	// RemovePlayer changes the slice of the players, so it is copied first
+	for _, p := range append([]*player.Player(nil), b.GetPlayers()...) {
 		b.RemovePlayer(p.ID)
 		// log.Printf("Removed player %s from board %s as the winner has been declared", p.ID, b.GetID())
 	}
@@ -1219,58 +1941,21 @@ func UpdateGameStatusAndAddStartTime(boardId string, status ludo_board_constants
 
 	BoardDAO := NewBoardDAO()
 
-	_, error := primitive.ObjectIDFromHex(boardId)
-
-	if error != nil {
-		log.Fatal(error)
-	}
-
 	err := BoardDAO.UpdateGameStatusAndAddStartTime(boardId, status, sTime)
 
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("failed to update the status of board %s: %w", boardId, err)
 	}
 
 	return nil
 }
 
-func CreateBoardInDB(boardId string, autoPlay bool, playersRequiredToStartGame int, ticketAmount int, rakeAmount int, rakeAmountType ludo_board_constants.RakeAmountType, autoPlayTimer int) primitive.M {
-
-	BoardDAO := NewBoardDAO()
-
-	game := BoardSchema{
-		ID:                         primitive.NewObjectID().Hex(),
-		BoardId:                    boardId,
-		Status:                     ludo_board_constants.WAITING,
-		AutoPlay:                   autoPlay,
-		AutoPlayTimer:              autoPlayTimer,
-		TicketAmount:               ticketAmount,
-		RakeAmount:                 rakeAmount,
-		RakeAmountType:             rakeAmountType,
-		PlayersRequiredToStartGame: playersRequiredToStartGame,
-		StartTime:                  nil,
-		EndTime:                    nil,
-		Winner:                     nil,
-		Players:                    []player.PlayerSchema{},
-		PawnMoves:                  make(map[string]map[string][]MoveSchema),
-	}
-
-	result, err := BoardDAO.InsertBoard(game)
-
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	return result
-}
-
 func AddPlayerToBoardInDB(boardId string, player player.PlayerSchema) error {
 	// Retrieve the game by boardId
 	game, err := NewBoardDAO().GetBoardById(boardId)
 
 	if err != nil {
-		log.Fatalf("failed to get game with ID %s: %v", boardId, err)
-		return err
+		return fmt.Errorf("failed to get game with ID %s: %w", boardId, err)
 	}
 
 	err = NewBoardDAO().AddPlayerToBoard(game.BoardId, player)
@@ -1305,10 +1990,10 @@ func UpdateBoardStatusAndAddEndTimeInDB(boardId string, status ludo_board_consta
 	return nil
 }
 
-func UpdateGameWinnerInDB(boardId, winner string, winningAmount int) error {
+func UpdateGameWinnerInDB(boardId string, winners []string, winningAmount int) error {
 	boardDAO := NewBoardDAO()
 
-	err := boardDAO.UpdateGameWinner(boardId, winner, winningAmount)
+	err := boardDAO.UpdateGameWinner(boardId, winners, winningAmount)
 	if err != nil {
 		log.Printf("Failed to update game winner in database: %v", err)
 		return err
@@ -1321,196 +2006,39 @@ func CreateBetTransaction(board *Board, playerId string) error {
 
 	player := board.GetPlayerByPlayerId(playerId)
 
-	payload := BetAndWinPayload{
-		WalletAddress: player.WalletAddress,
-		Amount:        float64(board.ticketAmount),
+	// Bots never pay, and practice tables are free
+	if player.IsBotPlayer() || board.ticketAmount == 0 {
+		return nil
 	}
 
 	// player.SetBetId(payload.BetId)
 
-	var response BetResponse
-	err := createTransaction(board, playerId, "/core/crypto/game/ludoBet", payload, &response)
-	if err != nil {
-		return err
-	}
-
-	if response.Code != "RS200" {
-		// log.Printf("Bet transaction failed with code: %s", response.Code)
-		switch response.Code {
-		case "RS405":
-			return fmt.Errorf(ludo_board_constants.RS405)
-		}
-	}
-
-	// log.Printf("Bet transaction completed successfully for player %s", playerId)
-	return nil
+	return board.wallet.Bet(player.WalletAddress, float64(board.ticketAmount))
 }
 
 func CreateWinTransaction(board *Board, playerId string, winningAmount int) error {
 
 	player := board.GetPlayerByPlayerId(playerId)
 
-	payload := BetAndWinPayload{
-		WalletAddress: player.WalletAddress,
-		Amount:        float64(winningAmount),
-	}
-
-	var response WinResponse
-
-	err := createTransaction(board, playerId, "/core/crypto/game/ludoWin", payload, &response)
-	if err != nil {
-		return err
-	}
-
-	if response.Code != "RS200" {
-		// log.Printf("Win transaction failed with code: %s", response.Code)
-		return fmt.Errorf("win transaction failed with code: %s", response.Code)
+	if player.IsBotPlayer() || board.ticketAmount == 0 {
+		return nil
 	}
 
 	// log.Printf("Win transaction completed successfully for player %s", playerId)
-	return nil
+	return board.wallet.Win(player.WalletAddress, float64(winningAmount))
 }
 
-func (b *Board) CreateRefundTransaction(playerId string, amount float64) error {
-	payload := RefundPayload{
-		PlayerId:        playerId,
-		Amount:          amount,
-		TransactionUuid: uuid.New().String(),
-		RequestUuid:     uuid.New().String(),
-		Currency:        "INR",
-		GameId:          b.GetID(),
-	}
-
-	var response RefundResponse
-	err := createTransaction(b, playerId, "/wallet/refund", payload, &response)
-	if err != nil {
-		return err
+func CreateRefundTransaction(board *Board, playerId string, amount float64) error {
+	if p := board.GetPlayerByPlayerId(playerId); (p != nil && p.IsBotPlayer()) || board.ticketAmount == 0 {
+		return nil
 	}
 
 	// log.Printf("Refund transaction completed successfully for player %s", playerId)
-	return nil
+	return board.wallet.Refund(playerId, amount, board.GetID())
 }
 
 func (b *Board) broadCastMessage(msg common.Message) {
-	socket.BroadcastMessage(msg, b.GetID())
-}
-
-func createTransaction(board *Board, playerId string, endpoint string, payload interface{}, response interface{}) error {
-	cfg := config.GetConfig()
-	// log.Printf("Creating transaction for player %s with ticket amount %d", playerId, board.ticketAmount)
-
-	if board.ticketAmount == 0 {
-		// log.Printf("Skipping transaction: ticket amount is 0")
-		return nil
-	}
-
-	// log.Printf("Created payload: %+v", payload)
-
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		// log.Printf("Failed to marshal payload: %v", err)
-		return fmt.Errorf("failed to marshal payload: %v", err)
-	}
-	// log.Printf("Marshalled JSON data: %s", string(jsonData))
-
-	// log.Printf("Making POST request to %s", cfg.BasePlatformAPIUrl+endpoint)
-	resp, err := http.Post(cfg.BasePlatformAPIUrl+endpoint, "application/json", bytes.NewBuffer(jsonData))
-	if err != nil {
-		// log.Printf("Failed to make transaction request: %v", err)
-		return err
-	}
-
-	defer resp.Body.Close()
-	// log.Printf("Received response with status code: %d", resp.StatusCode)
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		// log.Printf("Failed to read response body: %v", err)
-		return fmt.Errorf("failed to read response body: %v", err)
-	}
-	// log.Printf("Response body: %s", string(body))
-
-	if err := json.Unmarshal(body, &response); err != nil {
-		// log.Printf("Failed to unmarshal response: %v", err)
-		return fmt.Errorf("failed to parse response JSON: %v", err)
-	}
-	// log.Printf("Parsed response: %+v", response)
-
-	if resp.StatusCode != http.StatusOK {
-		// log.Printf("Transaction failed with HTTP status: %d", resp.StatusCode)
-		return fmt.Errorf("transaction failed with status: %d", resp.StatusCode)
-	}
-
-	return nil
-}
-
-type BetAndWinPayload struct {
-	WalletAddress string  `json:"walletAddress"`
-	Amount        float64 `json:"amount"`
-}
-
-type RefundPayload struct {
-	PlayerId        string  `json:"playerId"`
-	Amount          float64 `json:"amount"`
-	TransactionUuid string  `json:"transactionUuid"`
-	RequestUuid     string  `json:"requestUuid"`
-	Currency        string  `json:"currency"`
-	GameId          string  `json:"gameId"`
-}
-
-type BetResponse struct {
-	User        string  `json:"user"`
-	Code        string  `json:"code"`
-	Status      string  `json:"status"`
-	RequestUuid string  `json:"requestUuid"`
-	Balance     float64 `json:"balance"`
-}
-
-type RefundResponse struct {
-	User        string  `json:"user"`
-	Status      string  `json:"status"`
-	RequestUuid string  `json:"requestUuid"`
-	Balance     float64 `json:"balance"`
-}
-
-type WinResponse struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-	Data    struct {
-		PlayerResponse struct {
-			ID          string   `json:"_id"`
-			PhoneNumber string   `json:"phoneNumber"`
-			SkinID      string   `json:"skinId"`
-			Tags        []string `json:"tags"`
-			Balance     []struct {
-				// Define fields for balance object
-			} `json:"balance"`
-			BankDetails []struct {
-				// Define fields for bank details array
-			} `json:"bankDetails"`
-			Email          string  `json:"email"`
-			Name           string  `json:"name"`
-			CurrentBalance float64 `json:"currentBalance"`
-		} `json:"playerResponse"`
-		NewTransaction struct {
-			Player          string  `json:"player"`
-			Amount          float64 `json:"amount"`
-			SkinID          string  `json:"skinId"`
-			Currency        string  `json:"currency"`
-			TransactionType string  `json:"transactionType"`
-			MoneyType       string  `json:"moneyType"`
-			OpeningBalance  float64 `json:"openingBalance"`
-			ClosingBalance  float64 `json:"closingBalance"`
-			Details         []struct {
-				// Define fields for details object
-			} `json:"details"`
-			IsTransactionSuccess bool   `json:"isTransactionSuccess"`
-			ID                   string `json:"_id"`
-			CreatedAt            string `json:"createdAt"`
-			UpdatedAt            string `json:"updatedAt"`
-			Version              int    `json:"__v"`
-		} `json:"newTransaction"`
-	} `json:"data"`
+	b.transport.Broadcast(msg, b.GetID())
 }
 
 func (b *Board) GetExpectedMessage() *ExpectedMessage {
@@ -1518,6 +2046,7 @@ func (b *Board) GetExpectedMessage() *ExpectedMessage {
 }
 
 func (b *Board) UnsetExpectedMessage() {
+	b.lastExpectedMessage = b.expectedMessage
 	b.expectedMessage = nil
 }
 
@@ -1526,10 +2055,11 @@ func (b *Board) SetExpectedDiceRollMessage(quadrant string, timeout time.Duratio
 	b.expectedMessage = &ExpectedMessage{
 		EventName: ludo_board_constants.BOARD_DICEROLL,
 		Quadrant:  quadrant,
-		PlayerId:  b.GetPlayerByQuadrant(quadrant).GetPlayerId(),
+		PlayerId:  b.getPlayerIdByQuadrant(quadrant),
 		TStamp:    time.Now(),
 		Timeout:   timeout,
 	}
+	b.scheduleAutoPlay(b.expectedMessage)
 }
 
 func (b *Board) SetExpectedQuadrantSelectMessage(quadrant string, timeout time.Duration) {
@@ -1537,22 +2067,24 @@ func (b *Board) SetExpectedQuadrantSelectMessage(quadrant string, timeout time.D
 	b.expectedMessage = &ExpectedMessage{
 		EventName: ludo_board_constants.QUADRANT_SELECT,
 		Quadrant:  quadrant,
-		PlayerId:  b.GetPlayerByQuadrant(quadrant).GetPlayerId(),
+		PlayerId:  b.getPlayerIdByQuadrant(quadrant),
 		TStamp:    time.Now(),
 		Timeout:   timeout,
 	}
 }
 
-func (b *Board) SetExpectedMovePawnMessage(quadrant string, timeout time.Duration, steps int) {
+func (b *Board) SetExpectedMovePawnMessage(quadrant string, timeout time.Duration, steps int, remainingDice []int) {
 	// log.Printf("Setting expected move pawn message for quadrant %s", quadrant)
 	b.expectedMessage = &ExpectedMessage{
-		EventName: ludo_board_constants.BOARD_MOVEPAWN,
-		Quadrant:  quadrant,
-		PlayerId:  b.GetPlayerByQuadrant(quadrant).GetPlayerId(),
-		TStamp:    time.Now(),
-		Timeout:   timeout,
-		Steps:     steps,
+		EventName:     ludo_board_constants.BOARD_MOVEPAWN,
+		Quadrant:      quadrant,
+		PlayerId:      b.getPlayerIdByQuadrant(quadrant),
+		TStamp:        time.Now(),
+		Timeout:       timeout,
+		Steps:         steps,
+		RemainingDice: remainingDice,
 	}
+	b.scheduleAutoPlay(b.expectedMessage)
 }
 
 func (b *Board) SetExpectedTurnCompletedMessage(quadrant string, timeout time.Duration) {
@@ -1560,8 +2092,9 @@ func (b *Board) SetExpectedTurnCompletedMessage(quadrant string, timeout time.Du
 	b.expectedMessage = &ExpectedMessage{
 		EventName: ludo_board_constants.BOARD_TURN_COMPLETED,
 		Quadrant:  quadrant,
-		PlayerId:  b.GetPlayerByQuadrant(quadrant).GetPlayerId(),
+		PlayerId:  b.getPlayerIdByQuadrant(quadrant),
 		TStamp:    time.Now(),
 		Timeout:   timeout,
 	}
+	b.scheduleAutoPlay(b.expectedMessage)
 }
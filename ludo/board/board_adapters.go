@@ -0,0 +1,106 @@
+package board
+
+import (
+	"ludo/ludo_board_constants"
+	"ludo/player"
+	"messaging/common"
+	"messaging/socket"
+	"time"
+)
+
+// Transport delivers the board messages to the players and spectators
+type Transport interface {
+	Send(playerId string, msg common.Message, boardId string)
+	Broadcast(msg common.Message, boardId string)
+	BroadcastToSpectators(msg common.Message, boardId string)
+	HasSpectators(boardId string) bool
+	RemoteAddress(playerId string, boardId string) string // IP the player connected from, empty for in-process players
+}
+
+// Store persists what happens on the board
+type Store interface {
+	InsertBoard(board BoardSchema) error
+	AddPlayer(boardId string, player player.PlayerSchema) error
+	UpdateStatusAndStartTime(boardId string, status ludo_board_constants.BoardStatus, startTime time.Time) error
+	UpdateStatusAndEndTime(boardId string, status ludo_board_constants.BoardStatus, endTime time.Time) error
+	UpdateWinner(boardId string, winners []string, winningAmount int) error
+	UpdatePawnMovement(boardId, quadrant, pawn string, initialPosition, finalPosition int, timestamp time.Time, diceResult int) error
+	UpdatePlayerConnectionDetails(boardId, playerId string, cType string, time time.Time) error
+	AddViolation(boardId string, violation ViolationSchema) error
+	AddMoveRecord(boardId string, move MoveRecordSchema) error
+	SaveState(boardId string, state StateSchema) error
+}
+
+// Wallet moves the players' money on the platform
+type Wallet interface {
+	Bet(walletAddress string, amount float64) error
+	Win(walletAddress string, amount float64) error
+	Refund(playerId string, amount float64, gameId string) error
+}
+
+// socketTransport sends the messages through the websocket server
+type socketTransport struct{}
+
+func (socketTransport) Send(playerId string, msg common.Message, boardId string) {
+	socket.SendMessage(playerId, msg, boardId)
+}
+
+func (socketTransport) Broadcast(msg common.Message, boardId string) {
+	socket.BroadcastMessage(msg, boardId)
+}
+
+func (socketTransport) BroadcastToSpectators(msg common.Message, boardId string) {
+	socket.BroadcastToSpectators(msg, boardId)
+}
+
+func (socketTransport) HasSpectators(boardId string) bool {
+	return socket.HasSpectators(boardId)
+}
+
+func (socketTransport) RemoteAddress(playerId string, boardId string) string {
+	return socket.RemoteAddress(playerId, boardId)
+}
+
+// mongoStore persists the board in MongoDB
+type mongoStore struct{}
+
+func (mongoStore) InsertBoard(board BoardSchema) error {
+	_, err := NewBoardDAO().InsertBoard(board)
+	return err
+}
+
+func (mongoStore) AddPlayer(boardId string, player player.PlayerSchema) error {
+	return AddPlayerToBoardInDB(boardId, player)
+}
+
+func (mongoStore) UpdateStatusAndStartTime(boardId string, status ludo_board_constants.BoardStatus, startTime time.Time) error {
+	return UpdateGameStatusAndAddStartTime(boardId, status, startTime)
+}
+
+func (mongoStore) UpdateStatusAndEndTime(boardId string, status ludo_board_constants.BoardStatus, endTime time.Time) error {
+	return UpdateBoardStatusAndAddEndTimeInDB(boardId, status, endTime)
+}
+
+func (mongoStore) UpdateWinner(boardId string, winners []string, winningAmount int) error {
+	return UpdateGameWinnerInDB(boardId, winners, winningAmount)
+}
+
+func (mongoStore) UpdatePawnMovement(boardId, quadrant, pawn string, initialPosition, finalPosition int, timestamp time.Time, diceResult int) error {
+	return NewBoardDAO().UpdateBoardPawnMovement(boardId, quadrant, pawn, initialPosition, finalPosition, timestamp, diceResult)
+}
+
+func (mongoStore) UpdatePlayerConnectionDetails(boardId, playerId string, cType string, time time.Time) error {
+	return UpdatePlayerConnectionDetails(boardId, playerId, cType, time)
+}
+
+func (mongoStore) AddViolation(boardId string, violation ViolationSchema) error {
+	return NewBoardDAO().AddViolation(boardId, violation)
+}
+
+func (mongoStore) AddMoveRecord(boardId string, move MoveRecordSchema) error {
+	return NewBoardDAO().AddMoveRecord(boardId, move)
+}
+
+func (mongoStore) SaveState(boardId string, state StateSchema) error {
+	return NewBoardDAO().UpdateBoardState(boardId, state)
+}
@@ -14,6 +14,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type BoardDAO struct {
@@ -129,6 +130,99 @@ func (dao *BoardDAO) UpdateBoardPawnMovement(boardId, quadrant, pawn string, ini
 	return nil
 }
 
+func (dao *BoardDAO) AddViolation(boardId string, violation ViolationSchema) error {
+	filter := bson.M{"boardId": boardId}
+
+	update := bson.M{"$push": bson.M{"violations": violation}}
+
+	_, err := dao.collection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		log.Printf("AddViolation: Error adding violation to boardId %s: %v", boardId, err)
+		return fmt.Errorf("failed to add violation to game with ID %s: %v", boardId, err)
+	}
+
+	return nil
+}
+
+func (dao *BoardDAO) AddMoveRecord(boardId string, move MoveRecordSchema) error {
+	filter := bson.M{"boardId": boardId}
+
+	update := bson.M{"$push": bson.M{"moves": move}}
+
+	_, err := dao.collection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		log.Printf("AddMoveRecord: Error adding move to boardId %s: %v", boardId, err)
+		return fmt.Errorf("failed to add move to game with ID %s: %v", boardId, err)
+	}
+
+	return nil
+}
+
+// UpdateBoardState stores the state of a board being played
+func (dao *BoardDAO) UpdateBoardState(boardId string, state StateSchema) error {
+	filter := bson.M{"boardId": boardId}
+
+	update := bson.M{"$set": bson.M{"state": state}}
+
+	_, err := dao.collection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		log.Printf("UpdateBoardState: Error updating state of boardId %s: %v", boardId, err)
+		return fmt.Errorf("failed to update state of game with ID %s: %v", boardId, err)
+	}
+
+	return nil
+}
+
+// GetBoardsToAnalyze returns finished cash boards the anti-cheat analysis has not run on yet
+func (dao *BoardDAO) GetBoardsToAnalyze(limit int64) ([]BoardSchema, error) {
+	filter := bson.M{
+		"status":       ludo_board_constants.FINISHED,
+		"ticketAmount": bson.M{"$gt": 0},
+		"analyzed":     bson.M{"$ne": true},
+	}
+
+	cursor, err := dao.collection.Find(context.Background(), filter, options.Find().SetLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find games to analyze: %v", err)
+	}
+
+	var boards []BoardSchema
+	if err := cursor.All(context.Background(), &boards); err != nil {
+		return nil, fmt.Errorf("failed to decode games to analyze: %v", err)
+	}
+
+	return boards, nil
+}
+
+func (dao *BoardDAO) MarkBoardAnalyzed(boardId string) error {
+	filter := bson.M{"boardId": boardId}
+
+	update := bson.M{"$set": bson.M{"analyzed": true}}
+
+	_, err := dao.collection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to mark game with ID %s as analyzed: %v", boardId, err)
+	}
+
+	return nil
+}
+
+// CountCoSeatings returns how many cash boards started since the given time both players sat on
+func (dao *BoardDAO) CountCoSeatings(playerA string, playerB string, since time.Time) (int, error) {
+	filter := bson.M{
+		"ticketAmount":     bson.M{"$gt": 0},
+		"startTime":        bson.M{"$gte": since},
+		"players.playerId": bson.M{"$all": []string{playerA, playerB}},
+	}
+
+	count, err := dao.collection.CountDocuments(context.Background(), filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count games of players %s and %s: %v", playerA, playerB, err)
+	}
+
+	return int(count), nil
+}
+
 func (dao *BoardDAO) GetBoardById(boardId string) (*BoardSchema, error) {
 
 	// log.Println("GetBoardById: Fetching board with boardId: ", boardId)
@@ -184,12 +278,12 @@ func (dao *BoardDAO) UpdateBoardStatusAndAddEndTime(boardId string, status ludo_
 
 }
 
-func (dao *BoardDAO) UpdateGameWinner(boardId, winner string, winningAmount int) error {
+func (dao *BoardDAO) UpdateGameWinner(boardId string, winners []string, winningAmount int) error {
 
-	// log.Printf("UpdateGameWinner: Updating winner to %s with winning amount %d for boardId %s", winner, winningAmount, boardId)
+	// log.Printf("UpdateGameWinner: Updating winners to %v with winning amount %d for boardId %s", winners, winningAmount, boardId)
 	filter := bson.M{"boardId": boardId}
 
-	update := bson.M{"$set": bson.M{"winner": winner, "winningAmount": winningAmount}}
+	update := bson.M{"$set": bson.M{"winner": winners[0], "winners": winners, "winningAmount": winningAmount}}
 
 	_, err := dao.collection.UpdateOne(context.Background(), filter, update)
 
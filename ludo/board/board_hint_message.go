@@ -0,0 +1,78 @@
+package board
+
+import (
+	"encoding/json"
+	"messaging/common"
+)
+
+// HintMove is a movable pawn with the value the engine gave to moving it
+type HintMove struct {
+	Pawn  string  `json:"pawn"`
+	Score float64 `json:"score"`
+}
+
+// BoardHintMessage is sent on practice tables to the player who has to move, suggesting the best pawn.
+type BoardHintMessage struct {
+	common.Message
+	eventName     string
+	quadrant      string
+	steps         int
+	suggestedPawn string
+	moves         []HintMove
+}
+
+// NewBoardHintMessage creates a new BoardHintMessage.
+func NewBoardHintMessage(eventName string, quadrant string, steps int, suggestedPawn string, moves []HintMove) *BoardHintMessage {
+	return &BoardHintMessage{
+		eventName:     eventName,
+		quadrant:      quadrant,
+		steps:         steps,
+		suggestedPawn: suggestedPawn,
+		moves:         moves,
+	}
+}
+
+// GetSuggestedPawn returns the pawn the engine suggests to move.
+func (m *BoardHintMessage) GetSuggestedPawn() string {
+	return m.suggestedPawn
+}
+
+// ToJSON converts the BoardHintMessage to a JSON string.
+func (m *BoardHintMessage) ToJSON() (string, error) {
+	jsonData, err := json.Marshal(&struct {
+		EventName     string     `json:"eventName"`
+		Quadrant      string     `json:"quadrant"`
+		Steps         int        `json:"steps"`
+		SuggestedPawn string     `json:"suggestedPawn"`
+		Moves         []HintMove `json:"moves"`
+	}{
+		EventName:     m.eventName,
+		Quadrant:      m.quadrant,
+		Steps:         m.steps,
+		SuggestedPawn: m.suggestedPawn,
+		Moves:         m.moves,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+// ToObject converts a JSON string to a BoardHintMessage.
+func (m *BoardHintMessage) ToObject(data string) (common.Message, error) {
+	var intermediate struct {
+		EventName     string     `json:"eventName"`
+		Quadrant      string     `json:"quadrant"`
+		Steps         int        `json:"steps"`
+		SuggestedPawn string     `json:"suggestedPawn"`
+		Moves         []HintMove `json:"moves"`
+	}
+
+	err := json.Unmarshal([]byte(data), &intermediate)
+
+	if err != nil {
+		return &BoardHintMessage{}, err
+	}
+
+	return NewBoardHintMessage(intermediate.EventName, intermediate.Quadrant, intermediate.Steps, intermediate.SuggestedPawn, intermediate.Moves), nil
+}
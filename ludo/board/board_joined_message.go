@@ -13,6 +13,8 @@ type Player struct {
 type ParticipantInfo struct {
 	Player   Player `json:"player"`
 	Quadrant string `json:"quadrant"`
+	IsBot    bool   `json:"isBot"`
+	Team     string `json:"team,omitempty"`
 }
 
 type BoardJoinedMessage struct {
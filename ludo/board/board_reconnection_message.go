@@ -11,14 +11,14 @@ type BoardReconnectionMessage struct {
 	common.Message
 	eventName    string
 	Participants []ParticipantInfo
-	positions    []pawn.PawnPositions
+	snapshot     StateSnapshot // The reconnecting player resyncs from the full state
 }
 
-func NewBoardReconnectionMessage(participants []ParticipantInfo, positions []pawn.PawnPositions) *BoardReconnectionMessage {
+func NewBoardReconnectionMessage(participants []ParticipantInfo, snapshot StateSnapshot) *BoardReconnectionMessage {
 	return &BoardReconnectionMessage{
 		eventName:    ludo_board_constants.BOARD_RECONNECTION,
 		Participants: participants,
-		positions:    positions,
+		snapshot:     snapshot,
 	}
 }
 
@@ -27,10 +27,16 @@ func (m *BoardReconnectionMessage) ToJSON() (string, error) {
 		EventName    string               `json:"eventName"`
 		Participants []ParticipantInfo    `json:"participants"`
 		Positions    []pawn.PawnPositions `json:"positions"`
+		Turn         string               `json:"turn"`
+		Version      int                  `json:"version"`
+		Checksum     string               `json:"checksum"`
 	}{
 		EventName:    m.eventName,
 		Participants: m.Participants,
-		Positions:    m.positions,
+		Positions:    m.snapshot.Positions,
+		Turn:         m.snapshot.Turn,
+		Version:      m.snapshot.Version,
+		Checksum:     m.snapshot.Checksum,
 	})
 	if err != nil {
 		return "", err
@@ -0,0 +1,72 @@
+package board
+
+import (
+	"encoding/json"
+	"ludo/pawn"
+	"messaging/common"
+)
+
+// StateSnapshot is the full state of the board at a version, clients whose checksum does not match resync from it
+type StateSnapshot struct {
+	Version   int                  `json:"version"`
+	Checksum  string               `json:"checksum"`
+	Turn      string               `json:"turn"`
+	Positions []pawn.PawnPositions `json:"positions"`
+}
+
+// BoardStateSnapshotMessage answers the Board.Snapshot request of a player, it is sent to that player only.
+type BoardStateSnapshotMessage struct {
+	common.Message
+	eventName string
+	snapshot  StateSnapshot
+	requestId string // Request id of the Board.Snapshot it answers
+}
+
+// NewBoardStateSnapshotMessage creates a new BoardStateSnapshotMessage.
+func NewBoardStateSnapshotMessage(eventName string, snapshot StateSnapshot, requestId string) *BoardStateSnapshotMessage {
+	return &BoardStateSnapshotMessage{
+		eventName: eventName,
+		snapshot:  snapshot,
+		requestId: requestId,
+	}
+}
+
+// ToJSON converts the BoardStateSnapshotMessage to a JSON string.
+func (m *BoardStateSnapshotMessage) ToJSON() (string, error) {
+	jsonData, err := json.Marshal(&struct {
+		EventName string               `json:"eventName"`
+		Version   int                  `json:"version"`
+		Checksum  string               `json:"checksum"`
+		Turn      string               `json:"turn"`
+		Positions []pawn.PawnPositions `json:"positions"`
+		RequestId string               `json:"requestId,omitempty"`
+	}{
+		EventName: m.eventName,
+		Version:   m.snapshot.Version,
+		Checksum:  m.snapshot.Checksum,
+		Turn:      m.snapshot.Turn,
+		Positions: m.snapshot.Positions,
+		RequestId: m.requestId,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+// ToObject converts a JSON string to a BoardStateSnapshotMessage.
+func (m *BoardStateSnapshotMessage) ToObject(data string) (common.Message, error) {
+	var intermediate struct {
+		EventName string `json:"eventName"`
+		RequestId string `json:"requestId"`
+		StateSnapshot
+	}
+
+	err := json.Unmarshal([]byte(data), &intermediate)
+
+	if err != nil {
+		return &BoardStateSnapshotMessage{}, err
+	}
+
+	return NewBoardStateSnapshotMessage(intermediate.EventName, intermediate.StateSnapshot, intermediate.RequestId), nil
+}
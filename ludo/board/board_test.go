@@ -0,0 +1,148 @@
+package board
+
+import (
+	"ludo/ludo_board_constants"
+	"ludo/pawn"
+	"ludo/player"
+	"ludo/quadrant"
+	"ludo/rules"
+	"messaging/common"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingTransport struct {
+	messages []common.Message
+}
+
+func (t *recordingTransport) Send(playerId string, msg common.Message, boardId string) {
+	t.messages = append(t.messages, msg)
+}
+
+func (t *recordingTransport) Broadcast(msg common.Message, boardId string) {
+	t.messages = append(t.messages, msg)
+}
+
+func (t *recordingTransport) BroadcastToSpectators(msg common.Message, boardId string) {}
+func (t *recordingTransport) HasSpectators(boardId string) bool                        { return false }
+func (t *recordingTransport) RemoteAddress(playerId string, boardId string) string     { return "" }
+
+type memoryStore struct {
+	players []player.PlayerSchema
+	winners []string
+	moves   int
+}
+
+func (s *memoryStore) InsertBoard(board BoardSchema) error { return nil }
+func (s *memoryStore) AddPlayer(boardId string, player player.PlayerSchema) error {
+	s.players = append(s.players, player)
+	return nil
+}
+func (s *memoryStore) UpdateStatusAndStartTime(boardId string, status ludo_board_constants.BoardStatus, startTime time.Time) error {
+	return nil
+}
+func (s *memoryStore) UpdateStatusAndEndTime(boardId string, status ludo_board_constants.BoardStatus, endTime time.Time) error {
+	return nil
+}
+func (s *memoryStore) UpdateWinner(boardId string, winners []string, winningAmount int) error {
+	s.winners = winners
+	return nil
+}
+func (s *memoryStore) UpdatePawnMovement(boardId, quadrant, pawn string, initialPosition, finalPosition int, timestamp time.Time, diceResult int) error {
+	s.moves++
+	return nil
+}
+func (s *memoryStore) UpdatePlayerConnectionDetails(boardId, playerId string, cType string, time time.Time) error {
+	return nil
+}
+func (s *memoryStore) AddViolation(boardId string, violation ViolationSchema) error { return nil }
+func (s *memoryStore) AddMoveRecord(boardId string, move MoveRecordSchema) error    { return nil }
+func (s *memoryStore) SaveState(boardId string, state StateSchema) error            { return nil }
+
+type recordingWallet struct {
+	balance map[string]float64
+}
+
+func (w *recordingWallet) Bet(walletAddress string, amount float64) error {
+	w.balance[walletAddress] -= amount
+	return nil
+}
+
+func (w *recordingWallet) Win(walletAddress string, amount float64) error {
+	w.balance[walletAddress] += amount
+	return nil
+}
+
+func (w *recordingWallet) Refund(playerId string, amount float64, gameId string) error {
+	w.balance[playerId] += amount
+	return nil
+}
+
+func newTestBoard(rolls *[]int) (*Board, *recordingTransport, *memoryStore, *recordingWallet) {
+	transport := &recordingTransport{}
+	store := &memoryStore{}
+	wallet := &recordingWallet{balance: map[string]float64{}}
+
+	b := newBoard("board", 2, false, 100, 0, ludo_board_constants.FIXED, 0, rules.CLASSIC_RULES, 1, defaultQuadrantConfig(ludo_board_constants.CLASSIC_LAYOUT))
+	b.SetTransport(transport)
+	b.SetStore(store)
+	b.SetWallet(wallet)
+	b.SetStartOrder(ludo_board_constants.START_FIXED)
+	b.rollDie = func() int {
+		roll := (*rolls)[0]
+		*rolls = (*rolls)[1:]
+		return roll
+	}
+
+	return b, transport, store, wallet
+}
+
+func TestBoardPlaysToTheWinner(t *testing.T) {
+	rolls := []int{}
+	b, transport, store, wallet := newTestBoard(&rolls)
+
+	assert.NoError(t, b.AddPlayer("alice", "Alice", "w-alice"))
+	assert.NoError(t, b.AddPlayer("bob", "Bob", "w-bob"))
+
+	for _, playerId := range []string{"alice", "bob"} {
+		selected := b.GetAvailableQuadrants()[0]
+		_, err := b.SelectQuadrant(playerId, *quadrant.NewQuadrantSelectMessage(ludo_board_constants.QUADRANT_SELECT, selected))
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, ludo_board_constants.PLAYING, b.GetBoardStatus())
+	assert.Len(t, store.players, 2)
+	assert.Equal(t, -100.0, wallet.balance["w-alice"], "the ticket is paid when the quadrant is selected")
+
+	aliceQuadrant := b.GetPlayerByPlayerId("alice").GetQuadrant()
+	assert.Equal(t, aliceQuadrant, b.GetFirstTurn())
+
+	// A six unlocks the pawn and every six rolls again, so alice plays alone until her pawn finishes
+	steps := len(b.GetQuadrant(aliceQuadrant).GetPath()) - 1
+	rolls = append(rolls, 6)
+	for remaining := steps; remaining > 0; remaining -= min(remaining, 6) {
+		rolls = append(rolls, min(remaining, 6))
+	}
+
+	pawnName := b.GetQuadrant(aliceQuadrant).GetPawnNames()[0]
+	for len(rolls) > 0 {
+		value := rolls[0]
+		b.DiceRoll("alice")
+		assert.NoError(t, b.MovePawn("alice", *pawn.NewPawnMoveMessage(ludo_board_constants.BOARD_MOVEPAWN, aliceQuadrant, pawnName, value, 0)))
+		if !b.HasFinished() {
+			b.TurnCompleted()
+			assert.Equal(t, aliceQuadrant, b.state.TurnQuadrant(), "a six should roll again")
+		}
+	}
+
+	assert.Equal(t, ludo_board_constants.FINISHED, b.GetBoardStatus())
+	assert.Equal(t, []string{"alice"}, store.winners)
+	assert.Greater(t, store.moves, 1)
+	assert.Equal(t, 100.0, wallet.balance["w-alice"], "the winner takes the pot of both tickets")
+	assert.Equal(t, -100.0, wallet.balance["w-bob"])
+
+	_, ended := transport.messages[len(transport.messages)-1].(*GameEndMessage)
+	assert.True(t, ended, "the last message should end the game")
+}
@@ -0,0 +1,71 @@
+package board
+
+import (
+	"encoding/json"
+	"messaging/common"
+)
+
+// QuadrantWinProbability is the estimated chance of a quadrant winning the board
+type QuadrantWinProbability struct {
+	Quadrant    string  `json:"quadrant"`
+	PlayerId    string  `json:"playerId"`
+	Probability float64 `json:"probability"`
+}
+
+// WinProbabilities is the latest estimate of the board, computed after the given move
+type WinProbabilities struct {
+	MoveNumber    int                      `json:"moveNumber"`
+	Probabilities []QuadrantWinProbability `json:"probabilities"`
+}
+
+// SpectatorWinProbabilityMessage is sent only to the spectators of a board, shortly after the pawn moves.
+type SpectatorWinProbabilityMessage struct {
+	common.Message
+	eventName        string
+	winProbabilities WinProbabilities
+}
+
+// NewSpectatorWinProbabilityMessage creates a new SpectatorWinProbabilityMessage.
+func NewSpectatorWinProbabilityMessage(eventName string, winProbabilities WinProbabilities) *SpectatorWinProbabilityMessage {
+	return &SpectatorWinProbabilityMessage{
+		eventName:        eventName,
+		winProbabilities: winProbabilities,
+	}
+}
+
+// ToJSON converts the SpectatorWinProbabilityMessage to a JSON string.
+func (m *SpectatorWinProbabilityMessage) ToJSON() (string, error) {
+	jsonData, err := json.Marshal(&struct {
+		EventName     string                   `json:"eventName"`
+		MoveNumber    int                      `json:"moveNumber"`
+		Probabilities []QuadrantWinProbability `json:"probabilities"`
+	}{
+		EventName:     m.eventName,
+		MoveNumber:    m.winProbabilities.MoveNumber,
+		Probabilities: m.winProbabilities.Probabilities,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+// ToObject converts a JSON string to a SpectatorWinProbabilityMessage.
+func (m *SpectatorWinProbabilityMessage) ToObject(data string) (common.Message, error) {
+	var intermediate struct {
+		EventName     string                   `json:"eventName"`
+		MoveNumber    int                      `json:"moveNumber"`
+		Probabilities []QuadrantWinProbability `json:"probabilities"`
+	}
+
+	err := json.Unmarshal([]byte(data), &intermediate)
+
+	if err != nil {
+		return &SpectatorWinProbabilityMessage{}, err
+	}
+
+	return NewSpectatorWinProbabilityMessage(intermediate.EventName, WinProbabilities{
+		MoveNumber:    intermediate.MoveNumber,
+		Probabilities: intermediate.Probabilities,
+	}), nil
+}
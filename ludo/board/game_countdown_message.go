@@ -0,0 +1,58 @@
+package board
+
+import (
+	"encoding/json"
+	"messaging/common"
+	"time"
+)
+
+// GameCountdownMessage tells the players of a timed board how long is left before it ends by score.
+type GameCountdownMessage struct {
+	common.Message
+	eventName        string
+	remainingSeconds int
+	endsAt           time.Time
+}
+
+// NewGameCountdownMessage creates a new GameCountdownMessage.
+func NewGameCountdownMessage(eventName string, remainingSeconds int, endsAt time.Time) *GameCountdownMessage {
+	return &GameCountdownMessage{
+		eventName:        eventName,
+		remainingSeconds: remainingSeconds,
+		endsAt:           endsAt,
+	}
+}
+
+// ToJSON converts the GameCountdownMessage to a JSON string.
+func (m *GameCountdownMessage) ToJSON() (string, error) {
+	jsonData, err := json.Marshal(&struct {
+		EventName        string    `json:"eventName"`
+		RemainingSeconds int       `json:"remainingSeconds"`
+		EndsAt           time.Time `json:"endsAt"`
+	}{
+		EventName:        m.eventName,
+		RemainingSeconds: m.remainingSeconds,
+		EndsAt:           m.endsAt,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+// ToObject converts a JSON string to a GameCountdownMessage.
+func (m *GameCountdownMessage) ToObject(data string) (common.Message, error) {
+	var intermediate struct {
+		EventName        string    `json:"eventName"`
+		RemainingSeconds int       `json:"remainingSeconds"`
+		EndsAt           time.Time `json:"endsAt"`
+	}
+
+	err := json.Unmarshal([]byte(data), &intermediate)
+
+	if err != nil {
+		return &GameCountdownMessage{}, err
+	}
+
+	return NewGameCountdownMessage(intermediate.EventName, intermediate.RemainingSeconds, intermediate.EndsAt), nil
+}
@@ -0,0 +1,70 @@
+package board
+
+import (
+	"encoding/json"
+	"messaging/common"
+)
+
+// DiceOffRoll is the roll of one quadrant during a round of the dice-off
+type DiceOffRoll struct {
+	Quadrant string `json:"quadrant"`
+	PlayerId string `json:"playerId"`
+	Value    int    `json:"value"`
+}
+
+// GameDiceOffMessage tells the players the rolls of a round of the dice-off deciding who plays first.
+// Tied lists the quadrants rolling again, it is empty once the round has a single highest roll.
+type GameDiceOffMessage struct {
+	common.Message
+	eventName string
+	round     int
+	rolls     []DiceOffRoll
+	tied      []string
+}
+
+// NewGameDiceOffMessage creates a new GameDiceOffMessage.
+func NewGameDiceOffMessage(eventName string, round int, rolls []DiceOffRoll, tied []string) *GameDiceOffMessage {
+	return &GameDiceOffMessage{
+		eventName: eventName,
+		round:     round,
+		rolls:     rolls,
+		tied:      tied,
+	}
+}
+
+// ToJSON converts the GameDiceOffMessage to a JSON string.
+func (m *GameDiceOffMessage) ToJSON() (string, error) {
+	jsonData, err := json.Marshal(&struct {
+		EventName string        `json:"eventName"`
+		Round     int           `json:"round"`
+		Rolls     []DiceOffRoll `json:"rolls"`
+		Tied      []string      `json:"tied"`
+	}{
+		EventName: m.eventName,
+		Round:     m.round,
+		Rolls:     m.rolls,
+		Tied:      m.tied,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+// ToObject converts a JSON string to a GameDiceOffMessage.
+func (m *GameDiceOffMessage) ToObject(data string) (common.Message, error) {
+	var intermediate struct {
+		EventName string        `json:"eventName"`
+		Round     int           `json:"round"`
+		Rolls     []DiceOffRoll `json:"rolls"`
+		Tied      []string      `json:"tied"`
+	}
+
+	err := json.Unmarshal([]byte(data), &intermediate)
+
+	if err != nil {
+		return &GameDiceOffMessage{}, err
+	}
+
+	return NewGameDiceOffMessage(intermediate.EventName, intermediate.Round, intermediate.Rolls, intermediate.Tied), nil
+}
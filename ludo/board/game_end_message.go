@@ -10,24 +10,48 @@ type GameEndMessage struct {
 	common.Message
 	eventName     string
 	winner        string
-	winningAmount int
+	winners       []string // Every winning player, both partners in team games
+	winningAmount int      // Amount paid to each winner
 	responseCode  int
+	timeUp        bool            // The timed board ended by score
+	scoreboard    []QuadrantScore // Final ranking of timed boards
+}
+
+// QuadrantScore is the final standing of a quadrant on a timed board
+type QuadrantScore struct {
+	Rank          int    `json:"rank"`
+	Quadrant      string `json:"quadrant"`
+	PlayerId      string `json:"playerId"`
+	Progress      int    `json:"progress"`
+	FinishedPawns int    `json:"finishedPawns"`
+	Captures      int    `json:"captures"`
+	Score         int    `json:"score"`
+	TeamScore     int    `json:"teamScore"` // Score of the quadrant and its partner, the quadrant's own score without teams
 }
 
 // NewGameWinnerMessage creates a new GameEndMessage.
-func NewGameEndMessage(eventName string, winner string, winningAmount int, responseCode int) *GameEndMessage {
+func NewGameEndMessage(eventName string, winners []string, winningAmount int, responseCode int) *GameEndMessage {
 	return &GameEndMessage{
 		eventName:     eventName,
-		winner:        winner,
+		winner:        winners[0],
+		winners:       winners,
 		winningAmount: winningAmount,
 		responseCode:  responseCode,
 	}
 }
 
+// SetScoreboard adds the final ranking of a timed board to the message
+func (m *GameEndMessage) SetScoreboard(timeUp bool, scoreboard []QuadrantScore) *GameEndMessage {
+	m.timeUp = timeUp
+	m.scoreboard = scoreboard
+	return m
+}
+
 // GetGameEndMessage returns a copy of the GameEndMessage.
 func (m *GameEndMessage) GetGameEndMessage() GameEndMessage {
 	return GameEndMessage{
 		winner:       m.winner,
+		winners:      m.winners,
 		responseCode: m.responseCode,
 	}
 }
@@ -35,15 +59,21 @@ func (m *GameEndMessage) GetGameEndMessage() GameEndMessage {
 // ToJSON returns the JSON representation of the GameEndMessage.
 func (m *GameEndMessage) ToJSON() (string, error) {
 	jsonData, err := json.Marshal(&struct {
-		EventName     string `json:"eventName"`
-		Winner        string `json:"winner"`
-		WinningAmount int    `json:"winningAmount"`
-		ResponseCode  int    `json:"responseCode"`
+		EventName     string          `json:"eventName"`
+		Winner        string          `json:"winner"`
+		Winners       []string        `json:"winners"`
+		WinningAmount int             `json:"winningAmount"`
+		ResponseCode  int             `json:"responseCode"`
+		TimeUp        bool            `json:"timeUp,omitempty"`
+		Scoreboard    []QuadrantScore `json:"scoreboard,omitempty"`
 	}{
 		EventName:     m.eventName,
 		Winner:        m.winner,
+		Winners:       m.winners,
 		WinningAmount: m.winningAmount,
 		ResponseCode:  m.responseCode,
+		TimeUp:        m.timeUp,
+		Scoreboard:    m.scoreboard,
 	})
 	if err != nil {
 		return "", err
@@ -53,10 +83,13 @@ func (m *GameEndMessage) ToJSON() (string, error) {
 
 func (m *GameEndMessage) ToObject(data string) (common.Message, error) {
 	var intermediate struct {
-		EventName     string `json:"eventName"`
-		Winner        string `json:"winner"`
-		WinningAmount int    `json:"winningAmount"`
-		ResponseCode  int    `json:"responseCode"`
+		EventName     string          `json:"eventName"`
+		Winner        string          `json:"winner"`
+		Winners       []string        `json:"winners"`
+		WinningAmount int             `json:"winningAmount"`
+		ResponseCode  int             `json:"responseCode"`
+		TimeUp        bool            `json:"timeUp,omitempty"`
+		Scoreboard    []QuadrantScore `json:"scoreboard,omitempty"`
 	}
 
 	err := json.Unmarshal([]byte(data), &intermediate)
@@ -68,7 +101,10 @@ func (m *GameEndMessage) ToObject(data string) (common.Message, error) {
 	return &GameEndMessage{
 		eventName:     intermediate.EventName,
 		winner:        intermediate.Winner,
+		winners:       intermediate.Winners,
 		winningAmount: intermediate.WinningAmount,
 		responseCode:  intermediate.ResponseCode,
+		timeUp:        intermediate.TimeUp,
+		scoreboard:    intermediate.Scoreboard,
 	}, nil
 }
@@ -0,0 +1,57 @@
+package board
+
+import (
+	"encoding/json"
+	"messaging/common"
+)
+
+// GameFirstTurnMessage tells the players which quadrant plays first and how it was picked.
+type GameFirstTurnMessage struct {
+	common.Message
+	eventName  string
+	quadrant   string
+	startOrder string
+}
+
+// NewGameFirstTurnMessage creates a new GameFirstTurnMessage.
+func NewGameFirstTurnMessage(eventName string, quadrant string, startOrder string) *GameFirstTurnMessage {
+	return &GameFirstTurnMessage{
+		eventName:  eventName,
+		quadrant:   quadrant,
+		startOrder: startOrder,
+	}
+}
+
+// ToJSON converts the GameFirstTurnMessage to a JSON string.
+func (m *GameFirstTurnMessage) ToJSON() (string, error) {
+	jsonData, err := json.Marshal(&struct {
+		EventName  string `json:"eventName"`
+		Quadrant   string `json:"quadrant"`
+		StartOrder string `json:"startOrder"`
+	}{
+		EventName:  m.eventName,
+		Quadrant:   m.quadrant,
+		StartOrder: m.startOrder,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+// ToObject converts a JSON string to a GameFirstTurnMessage.
+func (m *GameFirstTurnMessage) ToObject(data string) (common.Message, error) {
+	var intermediate struct {
+		EventName  string `json:"eventName"`
+		Quadrant   string `json:"quadrant"`
+		StartOrder string `json:"startOrder"`
+	}
+
+	err := json.Unmarshal([]byte(data), &intermediate)
+
+	if err != nil {
+		return &GameFirstTurnMessage{}, err
+	}
+
+	return NewGameFirstTurnMessage(intermediate.EventName, intermediate.Quadrant, intermediate.StartOrder), nil
+}
@@ -2,6 +2,8 @@ package board
 
 import (
 	"encoding/json"
+	"ludo/quadrant"
+	"ludo/rules"
 	"messaging/common"
 )
 
@@ -10,6 +12,15 @@ type Quadrant struct {
 	Color string   `json:"color"`
 	Pawns []string `json:"pawns"`
 	Path  []int    `json:"path"`
+	Team  string   `json:"team,omitempty"`
+}
+
+// Grid is the table of the squares of the board, so clients draw it from the server data only
+type Grid struct {
+	Layout string          `json:"layout"`
+	Rows   int             `json:"rows"`
+	Cols   int             `json:"cols"`
+	Cells  []quadrant.Cell `json:"cells"`
 }
 
 type GameInitializeMessage struct {
@@ -22,9 +33,11 @@ type GameInitializeMessage struct {
 	ticketAmount               int
 	playersRequiredToStartGame int
 	playerSelectingTheQuadrant Player
+	ruleSet                    rules.RuleSet
+	grid                       Grid
 }
 
-func NewGameInitializeMessage(eventName string, safePositions []int, quadrants []Quadrant, autoPlay bool, playersRequiredToStartGame int, ticketAmount int, playerSelectingTheQuadrant Player, autoPlayTimer int) *GameInitializeMessage {
+func NewGameInitializeMessage(eventName string, safePositions []int, quadrants []Quadrant, autoPlay bool, playersRequiredToStartGame int, ticketAmount int, playerSelectingTheQuadrant Player, autoPlayTimer int, ruleSet rules.RuleSet, grid Grid) *GameInitializeMessage {
 	return &GameInitializeMessage{
 		eventName:                  eventName,
 		safePositions:              safePositions,
@@ -34,6 +47,8 @@ func NewGameInitializeMessage(eventName string, safePositions []int, quadrants [
 		ticketAmount:               ticketAmount,
 		playersRequiredToStartGame: playersRequiredToStartGame,
 		playerSelectingTheQuadrant: playerSelectingTheQuadrant,
+		ruleSet:                    ruleSet,
+		grid:                       grid,
 	}
 }
 
@@ -45,19 +60,23 @@ func (m *GameInitializeMessage) GetGameInitializeMessage() GameInitializeMessage
 		autoPlay:                   m.autoPlay,
 		ticketAmount:               m.ticketAmount,
 		playersRequiredToStartGame: m.playersRequiredToStartGame,
+		ruleSet:                    m.ruleSet,
+		grid:                       m.grid,
 	}
 }
 
 func (m *GameInitializeMessage) ToJSON() (string, error) {
 	jsonData, err := json.Marshal(&struct {
-		EventName                  string     `json:"eventName"`
-		SafePositions              []int      `json:"safePositions"`
-		Quadrants                  []Quadrant `json:"quadrants"`
-		AutoPlay                   bool       `json:"autoPlay"`
-		AutoPlayTimer              int        `json:"autoPlayTimer"`
-		PlayersRequiredToStartGame int        `json:"playersRequiredToStartGame"`
-		TicketAmount               int        `json:"ticketAmount"`
-		PlayerSelectingTheQuadrant Player     `json:"playerSelectingTheQuadrant"`
+		EventName                  string        `json:"eventName"`
+		SafePositions              []int         `json:"safePositions"`
+		Quadrants                  []Quadrant    `json:"quadrants"`
+		AutoPlay                   bool          `json:"autoPlay"`
+		AutoPlayTimer              int           `json:"autoPlayTimer"`
+		PlayersRequiredToStartGame int           `json:"playersRequiredToStartGame"`
+		TicketAmount               int           `json:"ticketAmount"`
+		PlayerSelectingTheQuadrant Player        `json:"playerSelectingTheQuadrant"`
+		RuleSet                    rules.RuleSet `json:"ruleSet"`
+		Grid                       Grid          `json:"grid"`
 	}{
 		EventName:                  m.eventName,
 		SafePositions:              m.safePositions,
@@ -67,6 +86,8 @@ func (m *GameInitializeMessage) ToJSON() (string, error) {
 		TicketAmount:               m.ticketAmount,
 		PlayersRequiredToStartGame: m.playersRequiredToStartGame,
 		PlayerSelectingTheQuadrant: m.playerSelectingTheQuadrant,
+		RuleSet:                    m.ruleSet,
+		Grid:                       m.grid,
 	})
 	if err != nil {
 		return "", err
@@ -76,14 +97,16 @@ func (m *GameInitializeMessage) ToJSON() (string, error) {
 
 func (m *GameInitializeMessage) ToObject(data string) (common.Message, error) {
 	var intermediate struct {
-		EventName                  string     `json:"eventName"`
-		SafePositions              []int      `json:"safePositions"`
-		Quadrants                  []Quadrant `json:"quadrants"`
-		AutoPlay                   bool       `json:"autoPlay"`
-		AutoPlayTimer              int        `json:"autoPlayTimer"`
-		PlayersRequiredToStartGame int        `json:"playersRequiredToStartGame"`
-		TicketAmount               int        `json:"ticketAmount"`
-		PlayerSelectingTheQuadrant Player     `json:"playerSelectingTheQuadrant"`
+		EventName                  string        `json:"eventName"`
+		SafePositions              []int         `json:"safePositions"`
+		Quadrants                  []Quadrant    `json:"quadrants"`
+		AutoPlay                   bool          `json:"autoPlay"`
+		AutoPlayTimer              int           `json:"autoPlayTimer"`
+		PlayersRequiredToStartGame int           `json:"playersRequiredToStartGame"`
+		TicketAmount               int           `json:"ticketAmount"`
+		PlayerSelectingTheQuadrant Player        `json:"playerSelectingTheQuadrant"`
+		RuleSet                    rules.RuleSet `json:"ruleSet"`
+		Grid                       Grid          `json:"grid"`
 	}
 
 	err := json.Unmarshal([]byte(data), &intermediate)
@@ -101,5 +124,7 @@ func (m *GameInitializeMessage) ToObject(data string) (common.Message, error) {
 		playersRequiredToStartGame: intermediate.PlayersRequiredToStartGame,
 		ticketAmount:               intermediate.TicketAmount,
 		playerSelectingTheQuadrant: intermediate.PlayerSelectingTheQuadrant,
+		ruleSet:                    intermediate.RuleSet,
+		grid:                       intermediate.Grid,
 	}, nil
 }
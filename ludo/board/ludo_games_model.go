@@ -2,10 +2,22 @@ package board
 
 import (
 	"ludo/ludo_board_constants"
+	"ludo/pawn"
 	"ludo/player"
+	"ludo/rules"
 	"time"
 )
 
+// StateSchema is the state of a board being played, stored when the server shuts down.
+// Nothing reads it back, restoring the boards is out of scope.
+type StateSchema struct {
+	Version   int                  `bson:"version" json:"version"`
+	Checksum  string               `bson:"checksum" json:"checksum"`
+	Turn      string               `bson:"turn" json:"turn"`
+	Positions []pawn.PawnPositions `bson:"positions" json:"positions"`
+	SavedAt   time.Time            `bson:"savedAt" json:"savedAt"`
+}
+
 // Move represents a single move made by a pawn
 type MoveSchema struct {
 	InitialPosition int       `bson:"initialPosition" json:"initialPosition"`
@@ -13,6 +25,29 @@ type MoveSchema struct {
 	Timestamp       time.Time `bson:"timestamp" json:"timestamp"`
 }
 
+// ViolationSchema is a pawn move the server rejected, kept for anti-cheat review
+type ViolationSchema struct {
+	PlayerId  string    `bson:"playerId" json:"playerId"`
+	Quadrant  string    `bson:"quadrant" json:"quadrant"`
+	Pawn      string    `bson:"pawn" json:"pawn"`
+	Steps     int       `bson:"steps" json:"steps"`
+	Code      string    `bson:"code" json:"code"`
+	Timestamp time.Time `bson:"timestamp" json:"timestamp"`
+}
+
+// MoveRecordSchema is a pawn move with the choice the player had, kept for anti-cheat review
+type MoveRecordSchema struct {
+	PlayerId            string    `bson:"playerId" json:"playerId"`
+	Quadrant            string    `bson:"quadrant" json:"quadrant"`
+	Pawn                string    `bson:"pawn" json:"pawn"`
+	Steps               int       `bson:"steps" json:"steps"`
+	CapturedQuadrants   []string  `bson:"capturedQuadrants" json:"capturedQuadrants"`     // Quadrants the move captured pawns of
+	CapturableQuadrants []string  `bson:"capturableQuadrants" json:"capturableQuadrants"` // Quadrants a legal move of the roll could capture pawns of
+	ReactionMs          int64     `bson:"reactionMs" json:"reactionMs"`                   // Time between the roll and the move
+	AutoPlayed          bool      `bson:"autoPlayed" json:"autoPlayed"`                   // Picked by the server or a bot, not by the player
+	Timestamp           time.Time `bson:"timestamp" json:"timestamp"`
+}
+
 // Game represents the overall game state
 type BoardSchema struct {
 	ID                         string                              `bson:"_id" json:"_id"`
@@ -25,9 +60,17 @@ type BoardSchema struct {
 	AutoPlay                   bool                                `bson:"autoPlay" json:"autoPlay"`
 	AutoPlayTimer              int                                 `bson:"autoPlayTimer" json:"autoPlayTimer"`
 	PlayersRequiredToStartGame int                                 `bson:"playersRequiredToStartGame" json:"playersRequiredToStartGame"`
+	RuleSet                    rules.RuleSet                       `bson:"ruleSet" json:"ruleSet"`
+	PawnsPerQuadrant           int                                 `bson:"pawnsPerQuadrant" json:"pawnsPerQuadrant"`
+	Layout                     string                              `bson:"layout" json:"layout"`
 	StartTime                  *time.Time                          `bson:"startTime,omitempty" json:"startTime,omitempty"`
 	EndTime                    *time.Time                          `bson:"endTime,omitempty" json:"endTime,omitempty"`
 	Winner                     *string                             `bson:"winner,omitempty" json:"winner,omitempty"`
+	Winners                    []string                            `bson:"winners,omitempty" json:"winners,omitempty"`
 	Players                    []player.PlayerSchema               `bson:"players" json:"players"`
 	PawnMoves                  map[string]map[string][]MoveSchema  `bson:"pawnMoves" json:"pawnMoves"`
+	Violations                 []ViolationSchema                   `bson:"violations,omitempty" json:"violations,omitempty"`
+	Moves                      []MoveRecordSchema                  `bson:"moves,omitempty" json:"moves,omitempty"`
+	Analyzed                   bool                                `bson:"analyzed,omitempty" json:"analyzed,omitempty"` // The anti-cheat analysis ran on the finished board
+	State                      *StateSchema                        `bson:"state,omitempty" json:"state,omitempty"`       // Stored when the server shut down during the game
 }
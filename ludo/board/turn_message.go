@@ -9,14 +9,14 @@ import (
 type TurnMessage struct {
 	eventName string
 	turn      string
-	positions []pawn.PawnPositions
+	sync      pawn.StateSync
 }
 
-func NewTurnMessage(eventName string, Turn string, pawnPositions []pawn.PawnPositions) *TurnMessage {
+func NewTurnMessage(eventName string, Turn string, sync pawn.StateSync) *TurnMessage {
 	return &TurnMessage{
 		turn:      Turn,
 		eventName: eventName,
-		positions: pawnPositions,
+		sync:      sync,
 	}
 }
 
@@ -30,11 +30,15 @@ func (m *TurnMessage) ToJSON() (string, error) {
 	jsonData, err := json.Marshal(&struct {
 		Turn      string               `json:"turn"`
 		EventName string               `json:"eventName"`
-		Positions []pawn.PawnPositions `json:"positions"`
+		Version   int                  `json:"version"`
+		Checksum  string               `json:"checksum"`
+		Changes   []pawn.PawnPositions `json:"changes"`
 	}{
 		Turn:      m.turn,
 		EventName: m.eventName,
-		Positions: m.positions,
+		Version:   m.sync.Version,
+		Checksum:  m.sync.Checksum,
+		Changes:   m.sync.Changes,
 	})
 	if err != nil {
 		return "", err
@@ -46,7 +50,9 @@ func (m *TurnMessage) ToObject(data string) (common.Message, error) {
 	var intermediate struct {
 		Turn      string               `json:"turn"`
 		EventName string               `json:"eventName"`
-		Positions []pawn.PawnPositions `json:"positions"`
+		Version   int                  `json:"version"`
+		Checksum  string               `json:"checksum"`
+		Changes   []pawn.PawnPositions `json:"changes"`
 	}
 
 	err := json.Unmarshal([]byte(data), &intermediate)
@@ -58,6 +64,10 @@ func (m *TurnMessage) ToObject(data string) (common.Message, error) {
 	return &TurnMessage{
 		turn:      intermediate.Turn,
 		eventName: intermediate.EventName,
-		positions: intermediate.Positions,
+		sync: pawn.StateSync{
+			Version:  intermediate.Version,
+			Checksum: intermediate.Checksum,
+			Changes:  intermediate.Changes,
+		},
 	}, nil
 }
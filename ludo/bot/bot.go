@@ -0,0 +1,251 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"ludo/ludo_board_constants"
+	"ludo/pawn"
+	"ludo/quadrant"
+	"ludo/rules"
+	"math/rand"
+	"messaging/common"
+	"messaging/socket"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Bot is a server-side player that fills an empty seat on a board.
+// It is attached to the socket layer like a connected player: it only learns about the board
+// from the messages broadcast to it, and it answers through the normal ProcessMessage path.
+type Bot struct {
+	id          string
+	name        string
+	boardId     string
+	quadrant    string
+	strategy    Strategy
+	gameService common.GameService
+	view        BoardView
+	dice        []int // Dice of the last roll of a two-dice game
+	stopped     bool
+	mu          sync.Mutex
+}
+
+// botEvent holds every field a bot reads from the board messages
+type botEvent struct {
+	EventName     string               `json:"eventName"`
+	Turn          string               `json:"turn"`
+	Quadrant      string               `json:"quadrant"`
+	RolledBy      string               `json:"rolledBy"`
+	Number        int                  `json:"number"`
+	MovablePawns  []string             `json:"movablePawns"`
+	Values        []int                `json:"values"`
+	ByDie         [][]string           `json:"movablePawnsByDie"`
+	RemainingDice []int                `json:"remainingDice"`
+	SafePositions []int                `json:"safePositions"`
+	Quadrants     json.RawMessage      `json:"quadrants"`
+	Positions     []pawn.PawnPositions `json:"positions"`
+	Checksum      string               `json:"checksum"`
+	Changes       []pawn.PawnPositions `json:"changes"`
+	RuleSet       rules.RuleSet        `json:"ruleSet"`
+	Participants  []struct {
+		Player struct {
+			Id string `json:"id"`
+		} `json:"player"`
+		Quadrant string `json:"quadrant"`
+	} `json:"participants"`
+}
+
+// NewBot creates a bot for the board playing with the strategy of the given difficulty
+func NewBot(boardId string, difficulty ludo_board_constants.BotDifficulty, gameService common.GameService) *Bot {
+	id := primitive.NewObjectID().Hex()
+
+	return &Bot{
+		id:          fmt.Sprintf("bot-%s", id),
+		name:        fmt.Sprintf("Bot %s", id[len(id)-4:]),
+		boardId:     boardId,
+		strategy:    NewStrategy(difficulty),
+		gameService: gameService,
+		view: BoardView{
+			Paths:   make(map[string][]int),
+			Teams:   make(map[string]string),
+			Playing: make(map[string]bool),
+		},
+	}
+}
+
+func (bt *Bot) GetId() string {
+	return bt.id
+}
+
+func (bt *Bot) GetName() string {
+	return bt.name
+}
+
+func (bt *Bot) GetBoardId() string {
+	return bt.boardId
+}
+
+// Join attaches the bot to the socket layer so it receives the board messages
+func (bt *Bot) Join() {
+	socket.AttachVirtualPlayer(bt.id, bt.boardId, bt.Receive)
+}
+
+// Leave stops the bot and detaches it from the socket layer
+func (bt *Bot) Leave() {
+	bt.mu.Lock()
+	bt.stopped = true
+	bt.mu.Unlock()
+
+	socket.DetachVirtualPlayer(bt.id, bt.boardId)
+}
+
+// Receive handles a message sent to the bot.
+// Messages are delivered while the board is still processing, so replies are sent from a separate goroutine.
+func (bt *Bot) Receive(msg string) {
+	var event botEvent
+	if err := json.Unmarshal([]byte(msg), &event); err != nil {
+		log.Printf("[Bot %s] Failed to parse message: %v", bt.id, err)
+		return
+	}
+
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	if bt.stopped {
+		return
+	}
+
+	if len(event.Positions) > 0 {
+		bt.view.Positions = event.Positions
+	} else if event.Checksum != "" {
+		// Messages changing the board only carry the pawns that moved, the bot resyncs when its positions drifted
+		bt.view.Positions = pawn.ApplyChanges(bt.view.Positions, event.Changes)
+		if pawn.StateChecksum(bt.view.Positions) != event.Checksum {
+			log.Printf("[Bot %s] Positions out of sync on board %s, asking for a snapshot", bt.id, bt.boardId)
+			go bt.reply(common.NewSocketMessage(ludo_board_constants.BOARD_SNAPSHOT, 0, ""))
+		}
+	}
+
+	switch event.EventName {
+	case ludo_board_constants.GAME_INITIALIZE:
+		var quadrants []struct {
+			Name string `json:"name"`
+			Path []int  `json:"path"`
+			Team string `json:"team"`
+		}
+		if err := json.Unmarshal(event.Quadrants, &quadrants); err == nil {
+			bt.view.Order = nil
+			for _, q := range quadrants {
+				bt.view.Order = append(bt.view.Order, q.Name)
+				bt.view.Paths[q.Name] = q.Path
+				bt.view.Teams[q.Name] = q.Team
+			}
+		}
+		bt.view.SafePositions = event.SafePositions
+		bt.view.RuleSet = event.RuleSet
+
+	case ludo_board_constants.SELECT_QUADRANT:
+		var available []string
+		if err := json.Unmarshal(event.Quadrants, &available); err != nil || len(available) == 0 {
+			return
+		}
+		selected := available[rand.Intn(len(available))]
+		go bt.reply(quadrant.NewQuadrantSelectMessage(ludo_board_constants.QUADRANT_SELECT, selected))
+
+	case ludo_board_constants.BOARD_JOINED:
+		for _, participant := range event.Participants {
+			bt.view.Playing[participant.Quadrant] = true
+			if participant.Player.Id == bt.id {
+				bt.quadrant = participant.Quadrant
+			}
+		}
+
+	case ludo_board_constants.TURN:
+		if bt.quadrant != "" && event.Turn == bt.quadrant {
+			go bt.reply(common.NewSocketMessage(ludo_board_constants.BOARD_DICEROLL, 0, ""))
+		}
+
+	case ludo_board_constants.BOARD_DICEROLLED:
+		if event.Quadrant != bt.quadrant || len(event.MovablePawns) == 0 {
+			return
+		}
+
+		if len(event.Values) > 1 {
+			// Two dice: the first die that moves a pawn is played first
+			bt.dice = event.Values
+			for die, movablePawns := range event.ByDie {
+				if len(movablePawns) > 0 {
+					bt.move(die, event.Values[die], movablePawns)
+					return
+				}
+			}
+			return
+		}
+
+		bt.move(0, event.Number, event.MovablePawns)
+
+	case ludo_board_constants.BOARD_PAWNMOVED:
+		if event.RolledBy != bt.quadrant {
+			return
+		}
+
+		for _, die := range event.RemainingDice {
+			if die >= len(bt.dice) {
+				continue
+			}
+			if movablePawns := bt.view.State(bt.quadrant).MovablePawns(bt.dice[die]); len(movablePawns) > 0 {
+				bt.move(die, bt.dice[die], movablePawns)
+				return
+			}
+		}
+
+		go bt.reply(common.NewSocketMessage(ludo_board_constants.BOARD_TURN_COMPLETED, 0, ""))
+
+	case ludo_board_constants.GAME_END:
+		bt.stopped = true
+		go socket.DetachVirtualPlayer(bt.id, bt.boardId)
+	}
+}
+
+// move picks one of the movable pawns and moves it with the die.
+// Searching can take a while, so the pawn is picked outside of the board's broadcast.
+func (bt *Bot) move(die int, steps int, movablePawns []string) {
+	view, quadrantName := bt.view, bt.quadrant
+	go func() {
+		pawnName := bt.strategy.SelectPawn(view, quadrantName, steps, movablePawns)
+		bt.reply(pawn.NewPawnMoveMessage(ludo_board_constants.BOARD_MOVEPAWN, quadrantName, pawnName, steps, die))
+	}()
+}
+
+// reply sends a message to the game service exactly as a socket client would
+func (bt *Bot) reply(msg common.Message) {
+	time.Sleep(ludo_board_constants.BOT_THINK_TIME)
+
+	bt.mu.Lock()
+	stopped := bt.stopped
+	bt.mu.Unlock()
+
+	if stopped {
+		return
+	}
+
+	body, err := msg.ToJSON()
+	if err != nil {
+		log.Printf("[Bot %s] Failed to marshal message: %v", bt.id, err)
+		return
+	}
+
+	req := common.SocketMessage{}
+	parsedReq, err := req.ToObject(body)
+	if err != nil {
+		log.Printf("[Bot %s] Invalid message %s: %v", bt.id, body, err)
+		return
+	}
+
+	if err := bt.gameService.ProcessMessage(bt.boardId, bt.id, parsedReq, []byte(body)); err != nil {
+		log.Printf("[Bot %s] Error %s when processing message %s", bt.id, err, body)
+	}
+}
@@ -0,0 +1,93 @@
+package bot
+
+import (
+	"ludo/engine"
+	"ludo/ludo_board_constants"
+	"ludo/pawn"
+	"ludo/rules"
+	"math/rand"
+)
+
+// BoardView is the part of the board a bot can see, built only from the messages it receives
+type BoardView struct {
+	SafePositions []int
+	Order         []string             // Quadrant names in turn order
+	Paths         map[string][]int     // Path of every quadrant
+	Teams         map[string]string    // Team of every quadrant, empty outside team games
+	Playing       map[string]bool      // Quadrants with a player
+	Positions     []pawn.PawnPositions // Latest pawn positions, -1 for idle pawns
+	RuleSet       rules.RuleSet        // Rules the board is played with
+}
+
+// State returns the rules state of the view with the turn given to the quadrant
+func (v BoardView) State(turn string) rules.State {
+	var playing []string
+	for _, name := range v.Order {
+		if v.Playing[name] {
+			playing = append(playing, name)
+		}
+	}
+
+	state := rules.FromPositions(v.Positions, v.Paths, v.SafePositions, playing, turn, v.RuleSet)
+	for i := range state.Quadrants {
+		state.Quadrants[i].Team = v.Teams[state.Quadrants[i].Name]
+	}
+	return state
+}
+
+// Strategy decides which of the movable pawns a bot moves
+type Strategy interface {
+	SelectPawn(view BoardView, quadrant string, steps int, movablePawns []string) string
+}
+
+// NewStrategy returns the strategy backing the given difficulty
+func NewStrategy(difficulty ludo_board_constants.BotDifficulty) Strategy {
+	switch difficulty {
+	case ludo_board_constants.BOT_EASY:
+		return &RandomStrategy{}
+	case ludo_board_constants.BOT_HARD:
+		return &EngineStrategy{depth: ludo_board_constants.BOT_HARD_SEARCH_DEPTH}
+	default:
+		return &EngineStrategy{depth: ludo_board_constants.BOT_MEDIUM_SEARCH_DEPTH}
+	}
+}
+
+// RandomStrategy moves any of the movable pawns
+type RandomStrategy struct{}
+
+func (s *RandomStrategy) SelectPawn(view BoardView, quadrant string, steps int, movablePawns []string) string {
+	if len(movablePawns) == 0 {
+		return ""
+	}
+	return movablePawns[rand.Intn(len(movablePawns))]
+}
+
+// EngineStrategy moves the pawn the engine values most, searching depth rolls ahead.
+// It plays randomly on boards whose rules the engine does not support.
+type EngineStrategy struct {
+	depth int
+}
+
+func (s *EngineStrategy) SelectPawn(view BoardView, quadrant string, steps int, movablePawns []string) string {
+	if !engine.Supports(view.RuleSet) {
+		return (&RandomStrategy{}).SelectPawn(view, quadrant, steps, movablePawns)
+	}
+
+	best, ok := engine.Best(engine.ScoreMoves(view.State(quadrant), steps, s.depth))
+
+	if !ok || !contains(movablePawns, best.PawnName) {
+		// The view is out of date, fall back to what the server allows
+		return (&RandomStrategy{}).SelectPawn(view, quadrant, steps, movablePawns)
+	}
+
+	return best.PawnName
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
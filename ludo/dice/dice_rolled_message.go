@@ -11,6 +11,11 @@ type DiceRolledMessage struct {
 	number       int
 	quadrant     string
 	movablePawns []string
+	forfeited    bool // The roll was the third 6 in a row and ended the turn
+
+	// Two-dice games only
+	values            []int      // Both dice, number is the first one
+	movablePawnsByDie [][]string // Pawns each die can move
 }
 
 func NewDiceRolledMessage(
@@ -18,35 +23,50 @@ func NewDiceRolledMessage(
 	number int,
 	quadrant string,
 	movablePawns []string,
+	forfeited bool,
+	values []int,
+	movablePawnsByDie [][]string,
 ) *DiceRolledMessage {
 	return &DiceRolledMessage{
-		number:       number,
-		eventName:    eventName,
-		quadrant:     quadrant,
-		movablePawns: movablePawns,
+		number:            number,
+		eventName:         eventName,
+		quadrant:          quadrant,
+		movablePawns:      movablePawns,
+		forfeited:         forfeited,
+		values:            values,
+		movablePawnsByDie: movablePawnsByDie,
 	}
 }
 
 func (m *DiceRolledMessage) GetDiceRolledMessage() DiceRolledMessage {
 
 	return DiceRolledMessage{
-		number:       m.number,
-		quadrant:     m.quadrant,
-		movablePawns: m.movablePawns,
+		number:            m.number,
+		quadrant:          m.quadrant,
+		movablePawns:      m.movablePawns,
+		forfeited:         m.forfeited,
+		values:            m.values,
+		movablePawnsByDie: m.movablePawnsByDie,
 	}
 }
 
 func (m *DiceRolledMessage) ToJSON() (string, error) {
 	jsonData, err := json.Marshal(&struct {
-		Number       int      `json:"number"`
-		EventName    string   `json:"eventName"`
-		MovablePawns []string `json:"movablePawns"`
-		Quadrant     string   `json:"quadrant"`
+		Number            int        `json:"number"`
+		EventName         string     `json:"eventName"`
+		MovablePawns      []string   `json:"movablePawns"`
+		Quadrant          string     `json:"quadrant"`
+		Forfeited         bool       `json:"forfeited"`
+		Values            []int      `json:"values,omitempty"`
+		MovablePawnsByDie [][]string `json:"movablePawnsByDie,omitempty"`
 	}{
-		Number:       m.number,
-		EventName:    m.eventName,
-		MovablePawns: m.movablePawns,
-		Quadrant:     m.quadrant,
+		Number:            m.number,
+		EventName:         m.eventName,
+		MovablePawns:      m.movablePawns,
+		Quadrant:          m.quadrant,
+		Forfeited:         m.forfeited,
+		Values:            m.values,
+		MovablePawnsByDie: m.movablePawnsByDie,
 	})
 	if err != nil {
 		return "", err
@@ -56,10 +76,13 @@ func (m *DiceRolledMessage) ToJSON() (string, error) {
 
 func (m *DiceRolledMessage) ToObject(data string) (common.Message, error) {
 	var intermediate struct {
-		Number       int      `json:"number"`
-		EventName    string   `json:"eventName"`
-		Quadrant     string   `json:"quadrant"`
-		MovablePawns []string `json:"movablePawns"`
+		Number            int        `json:"number"`
+		EventName         string     `json:"eventName"`
+		Quadrant          string     `json:"quadrant"`
+		MovablePawns      []string   `json:"movablePawns"`
+		Forfeited         bool       `json:"forfeited"`
+		Values            []int      `json:"values,omitempty"`
+		MovablePawnsByDie [][]string `json:"movablePawnsByDie,omitempty"`
 	}
 
 	err := json.Unmarshal([]byte(data), &intermediate)
@@ -69,9 +92,12 @@ func (m *DiceRolledMessage) ToObject(data string) (common.Message, error) {
 	}
 
 	return &DiceRolledMessage{
-		number:       intermediate.Number,
-		eventName:    intermediate.EventName,
-		quadrant:     intermediate.Quadrant,
-		movablePawns: intermediate.MovablePawns,
+		number:            intermediate.Number,
+		eventName:         intermediate.EventName,
+		quadrant:          intermediate.Quadrant,
+		movablePawns:      intermediate.MovablePawns,
+		forfeited:         intermediate.Forfeited,
+		values:            intermediate.Values,
+		movablePawnsByDie: intermediate.MovablePawnsByDie,
 	}, nil
 }
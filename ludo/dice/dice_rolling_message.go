@@ -12,8 +12,8 @@ type DiceRollingMessage struct {
 
 func NewDiceRollingMessage(
 	eventName string,
-) *DiceRolledMessage {
-	return &DiceRolledMessage{
+) *DiceRollingMessage {
+	return &DiceRollingMessage{
 		eventName: eventName,
 	}
 }
@@ -41,7 +41,7 @@ func (m *DiceRollingMessage) ToObject(data string) (common.Message, error) {
 		return nil, err
 	}
 
-	return &DiceRolledMessage{
+	return &DiceRollingMessage{
 		eventName: intermediate.EventName,
 	}, nil
 }
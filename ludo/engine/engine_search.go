@@ -0,0 +1,255 @@
+package engine
+
+import (
+	"ludo/rules"
+	"math/rand"
+)
+
+const (
+	winValue        = 1000.0 // Value of a finished game for the winner
+	unlockValue     = 10.0   // Value of a pawn as soon as it leaves idle
+	finishValue     = 30.0   // Extra value of a pawn that reached the end of its path
+	safeValue       = 3.0    // Extra value of a pawn resting on a safe position
+	threatRiskRatio = 0.4    // Share of a pawn's value lost when an opponent can reach it with one roll
+	maxPlayoutTurns = 1000   // Moves after which a playout is scored instead of played out
+)
+
+// ScoredMove is a legal move with the value the engine gave it
+type ScoredMove struct {
+	Move     rules.Move
+	PawnName string
+	Score    float64
+}
+
+// Supports reports whether the engine plays the rules faithfully. It rolls a single die and plays games until
+// a quadrant wins, so boards rolling two dice or ending on time are left to other strategies.
+func Supports(r rules.RuleSet) bool {
+	return !r.TwoDice && !r.IsTimed()
+}
+
+// Evaluate scores the state from the point of view of quadrant me.
+// Progress, safe positions and finished pawns count for a quadrant, pawns opponents can capture count against it,
+// and the result is the quadrant's score minus the score of its strongest opponent. In team games the partner's score counts as its own.
+func Evaluate(s rules.State, me int) float64 {
+	if winner := s.Winner(); winner != -1 {
+		if s.SameTeam(winner, me) {
+			return winValue
+		}
+		return -winValue
+	}
+
+	myScore := 0.0
+	bestOpponentScore := 0.0
+	first := true
+
+	for i := range s.Quadrants {
+		score := quadrantScore(s, i)
+		if s.SameTeam(i, me) {
+			myScore += score
+			continue
+		}
+		if first || score > bestOpponentScore {
+			bestOpponentScore = score
+			first = false
+		}
+	}
+
+	return myScore - bestOpponentScore
+}
+
+func quadrantScore(s rules.State, quadrant int) float64 {
+	q := s.Quadrants[quadrant]
+	score := 0.0
+
+	for _, index := range q.Pawns {
+		if index == -1 {
+			continue
+		}
+
+		value := unlockValue + float64(index)
+		position := q.Path[index]
+
+		switch {
+		case index == len(q.Path)-1:
+			value += finishValue
+		case s.IsSafe(position):
+			value += safeValue
+		case IsThreatened(s, quadrant, position):
+			value -= (unlockValue + float64(index)) * threatRiskRatio
+		}
+
+		score += value
+	}
+
+	return score
+}
+
+// IsThreatened reports whether a pawn of an opposing quadrant can land on the position with a single roll, partners never capture
+func IsThreatened(s rules.State, quadrant int, position int) bool {
+	for i, q := range s.Quadrants {
+		if s.SameTeam(i, quadrant) {
+			continue
+		}
+		target := rules.IndexInPath(q.Path, position)
+		if target == -1 {
+			continue
+		}
+		for _, index := range q.Pawns {
+			if index != -1 && target > index && target-index <= 6 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Expectiminimax returns the expected value of the state for quadrant me, looking depth rolls ahead.
+// Every roll is a chance node over the six dice values, quadrant me picks its best move and opponents pick the move worst for me.
+func Expectiminimax(s rules.State, me int, depth int) float64 {
+	if depth <= 0 || s.Winner() != -1 {
+		return Evaluate(s, me)
+	}
+
+	total := 0.0
+	for steps := 1; steps <= 6; steps++ {
+		total += bestReply(s, me, steps, depth)
+	}
+
+	return total / 6
+}
+
+func bestReply(s rules.State, me int, steps int, depth int) float64 {
+	moves := s.LegalMoves(steps)
+	if len(moves) == 0 {
+		return Expectiminimax(s.Pass(steps), me, depth-1)
+	}
+
+	maximizing := s.SameTeam(s.Turn, me)
+	best := 0.0
+
+	for i, move := range moves {
+		next, _ := s.Apply(move)
+		value := Expectiminimax(next, me, depth-1)
+		if i == 0 || (maximizing && value > best) || (!maximizing && value < best) {
+			best = value
+		}
+	}
+
+	return best
+}
+
+// ScoreMoves returns the legal moves of the quadrant having the turn, valued by expectiminimax.
+// A depth of 1 only looks at the position right after the move.
+func ScoreMoves(s rules.State, steps int, depth int) []ScoredMove {
+	var scoredMoves []ScoredMove
+
+	me := s.Turn
+	for _, move := range s.LegalMoves(steps) {
+		next, _ := s.Apply(move)
+		scoredMoves = append(scoredMoves, ScoredMove{
+			Move:     move,
+			PawnName: s.PawnName(move),
+			Score:    Expectiminimax(next, me, depth-1),
+		})
+	}
+
+	return scoredMoves
+}
+
+// MonteCarlo returns the legal moves of the quadrant having the turn,
+// valued by the share of random playouts the quadrant wins after making them.
+func MonteCarlo(s rules.State, steps int, rollouts int, rnd *rand.Rand) []ScoredMove {
+	var scoredMoves []ScoredMove
+
+	me := s.Turn
+	for _, move := range s.LegalMoves(steps) {
+		next, _ := s.Apply(move)
+
+		wins := 0
+		for i := 0; i < rollouts; i++ {
+			if next.SameTeam(Playout(next, rnd), me) {
+				wins++
+			}
+		}
+
+		scoredMoves = append(scoredMoves, ScoredMove{
+			Move:     move,
+			PawnName: s.PawnName(move),
+			Score:    float64(wins) / float64(rollouts),
+		})
+	}
+
+	return scoredMoves
+}
+
+// Best returns the move with the highest score
+func Best(scoredMoves []ScoredMove) (ScoredMove, bool) {
+	if len(scoredMoves) == 0 {
+		return ScoredMove{}, false
+	}
+
+	best := scoredMoves[0]
+	for _, scoredMove := range scoredMoves[1:] {
+		if scoredMove.Score > best.Score {
+			best = scoredMove
+		}
+	}
+
+	return best, true
+}
+
+// Playout plays random rolls and random legal moves from the state until a quadrant wins and returns its index.
+// Playouts that run too long are won by the quadrant with the most progress.
+func Playout(s rules.State, rnd *rand.Rand) int {
+	current := s.Clone()
+
+	for turn := 0; turn < maxPlayoutTurns; turn++ {
+		if winner := current.Winner(); winner != -1 {
+			return winner
+		}
+
+		steps := rnd.Intn(6) + 1
+		moves := current.LegalMoves(steps)
+		if len(moves) == 0 {
+			if !current.Rules.KeepsTurn(steps) {
+				current.PassTurn()
+			}
+			continue
+		}
+
+		current.Play(moves[rnd.Intn(len(moves))])
+	}
+
+	leader := 0
+	for i := range current.Quadrants {
+		if quadrantScore(current, i) > quadrantScore(current, leader) {
+			leader = i
+		}
+	}
+	return leader
+}
+
+// WinProbabilities plays random continuations of the state and returns, by quadrant name, the share of them each quadrant won
+func WinProbabilities(s rules.State, rollouts int, rnd *rand.Rand) map[string]float64 {
+	probabilities := make(map[string]float64)
+
+	if len(s.Quadrants) == 0 || rollouts <= 0 {
+		return probabilities
+	}
+
+	wins := make([]int, len(s.Quadrants))
+	for i := 0; i < rollouts; i++ {
+		winner := Playout(s, rnd)
+		for j := range s.Quadrants {
+			if s.SameTeam(j, winner) {
+				wins[j]++
+			}
+		}
+	}
+
+	for i, q := range s.Quadrants {
+		probabilities[q.Name] = float64(wins[i]) / float64(rollouts)
+	}
+
+	return probabilities
+}
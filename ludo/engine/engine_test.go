@@ -0,0 +1,46 @@
+package engine
+
+import (
+	"ludo/rules"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreMovesPrefersCapture(t *testing.T) {
+	state := rules.State{
+		SafePositions: []int{1},
+		Quadrants: []rules.QuadrantState{
+			{Name: "QUADRANT_1", Path: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, PawnNames: []string{"Q1_P1", "Q1_P2"}, Pawns: []int{0, 2}},
+			{Name: "QUADRANT_2", Path: []int{6, 7, 8, 9, 10, 1, 2, 3, 4, 20}, PawnNames: []string{"Q2_P1", "Q2_P2"}, Pawns: []int{-1, 0}},
+		},
+		Rules: rules.CLASSIC_RULES,
+	}
+
+	best, ok := Best(ScoreMoves(state, 3, 2))
+
+	assert.True(t, ok)
+	assert.Equal(t, "Q1_P2", best.PawnName, "The engine should pick the capturing move")
+}
+
+func TestSupportsOnlyOneDieUntimedRules(t *testing.T) {
+	assert.True(t, Supports(rules.CLASSIC_RULES))
+	assert.True(t, Supports(rules.RULE_SETS[rules.TEAMS]))
+	assert.False(t, Supports(rules.RULE_SETS[rules.TWO_DICE]), "The engine should not search two dice rolls")
+	assert.False(t, Supports(rules.RULE_SETS[rules.QUICK]), "The engine should not search timed boards")
+}
+
+func TestPartnersDoNotThreaten(t *testing.T) {
+	state := rules.State{
+		Quadrants: []rules.QuadrantState{
+			{Name: "QUADRANT_1", Path: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, PawnNames: []string{"Q1_P1"}, Pawns: []int{4}, Team: "TEAM_1"},
+			{Name: "QUADRANT_2", Path: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, PawnNames: []string{"Q2_P1"}, Pawns: []int{0}, Team: "TEAM_1"},
+		},
+		Rules: rules.RULE_SETS[rules.TEAMS],
+	}
+
+	assert.False(t, IsThreatened(state, 0, 5), "A partner pawn behind should not be a threat")
+
+	state.Quadrants[1].Team = "TEAM_2"
+	assert.True(t, IsThreatened(state, 0, 5), "An opponent pawn behind should be a threat")
+}
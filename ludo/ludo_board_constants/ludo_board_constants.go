@@ -1,5 +1,10 @@
 package ludo_board_constants
 
+import "time"
+
+// GAME is the name of the game, the game services are registered as it and the platform wallet books its bets and wins under it
+const GAME = "ludo"
+
 type BoardStatus string
 
 const (
@@ -7,6 +12,8 @@ const (
 	PLAYING   BoardStatus = "PLAYING"   // Game in progress
 	FINISHED  BoardStatus = "FINISHED"  // Game completed
 	DISCARDED BoardStatus = "DISCARDED" // Game discarded
+
+	UNAVAILABLE BoardStatus = "UNAVAILABLE" // Closed to new players while the server shuts down, the game never starts
 )
 
 // PawnStatus represents the current state of a pawn in the game
@@ -23,26 +30,34 @@ const (
 )
 
 const (
-	GAME_INITIALIZE          = "Game.Initialize"
-	SELECT_QUADRANT          = "Select.Quadrant"
-	QUADRANT_SELECT          = "Board.SelectQuadrant"
-	BOARD_JOINED             = "Board.Joined"
-	GAME_START               = "Game.Start"
-	TURN                     = "Turn"
-	BOARD_DICEROLL           = "Board.DiceRoll"
-	BOARD_DICEROLLED         = "Board.DiceRolled"
-	BOARD_MOVEPAWN           = "Board.MovePawn"
-	BOARD_MOVINGPAWN         = "Board.MovingPawn"
-	BOARD_PAWNMOVED          = "Board.PawnMoved"
-	BOARD_TURN_COMPLETED     = "Board.TurnCompleted"
-	GAME_WINNER              = "Game.Winner"
-	GAME_END                 = "Game.End"
-	BOARD_DICEROLLING        = "Board.DiceRolling"
-	PLAYER_DISCONNECTED      = "Player.Disconnected"
-	BOARD_RECONNECTION       = "Player.Reconnected"
-	BOARD_BET_FAILED         = "Board.BetFailed"
-	BOARD_WAITING_PLAYERS    = "Board.WaitingPlayers"
-	BOARD_SELECTING_QUADRANT = "Board.SelectingQuadrant"
+	GAME_INITIALIZE           = "Game.Initialize"
+	SELECT_QUADRANT           = "Select.Quadrant"
+	QUADRANT_SELECT           = "Board.SelectQuadrant"
+	BOARD_JOINED              = "Board.Joined"
+	GAME_START                = "Game.Start"
+	TURN                      = "Turn"
+	BOARD_DICEROLL            = "Board.DiceRoll"
+	BOARD_DICEROLLED          = "Board.DiceRolled"
+	BOARD_MOVEPAWN            = "Board.MovePawn"
+	BOARD_MOVINGPAWN          = "Board.MovingPawn"
+	BOARD_PAWNMOVED           = "Board.PawnMoved"
+	BOARD_TURN_COMPLETED      = "Board.TurnCompleted"
+	GAME_WINNER               = "Game.Winner"
+	GAME_END                  = "Game.End"
+	BOARD_DICEROLLING         = "Board.DiceRolling"
+	PLAYER_DISCONNECTED       = "Player.Disconnected"
+	BOARD_RECONNECTION        = "Player.Reconnected"
+	BOARD_BET_FAILED          = "Board.BetFailed"
+	BOARD_WAITING_PLAYERS     = "Board.WaitingPlayers"
+	BOARD_SELECTING_QUADRANT  = "Board.SelectingQuadrant"
+	BOARD_HINT                = "Board.Hint"
+	SPECTATOR_WIN_PROBABILITY = "Spectator.WinProbability"
+	GAME_COUNTDOWN            = "Game.Countdown"
+	GAME_DICE_OFF             = "Game.DiceOff"
+	GAME_FIRST_TURN           = "Game.FirstTurn"
+	BOARD_MOVE_REJECTED       = "Board.MoveRejected"
+	BOARD_SNAPSHOT            = "Board.Snapshot"      // Asked by a client whose checksum does not match
+	BOARD_STATE_SNAPSHOT      = "Board.StateSnapshot" // Full state answering Board.Snapshot
 )
 
 const (
@@ -59,6 +74,7 @@ const (
 	SELECT    MethodName = "SelectQuadrant"
 	MOVE_PAWN MethodName = "MovePawn"
 	DICEROLL  MethodName = "DiceRoll"
+	SNAPSHOT  MethodName = "Snapshot"
 )
 
 var SafePositions = []int{91, 36, 23, 102, 133, 188, 201, 122}
@@ -70,6 +86,39 @@ var QuadrantsNames = map[int]string{
 	4: "QUADRANT_4",
 }
 
+// Layouts of the board, the geometry of each is stored in the quadrantConfig collection
+const (
+	CLASSIC_LAYOUT = "CLASSIC" // The 15x15 board with four quadrants
+	RING_3_LAYOUT  = "RING_3"
+	RING_5_LAYOUT  = "RING_5"
+	RING_6_LAYOUT  = "RING_6"
+)
+
+// BOARD_LAYOUTS is the layout boards are played on, by number of players
+var BOARD_LAYOUTS = map[int]string{
+	2: CLASSIC_LAYOUT,
+	3: RING_3_LAYOUT,
+	4: CLASSIC_LAYOUT,
+	5: RING_5_LAYOUT,
+	6: RING_6_LAYOUT,
+}
+
+// RING_LAYOUTS is the number of quadrants of every ring shaped layout
+var RING_LAYOUTS = map[string]int{
+	RING_3_LAYOUT: 3,
+	RING_5_LAYOUT: 5,
+	RING_6_LAYOUT: 6,
+}
+
+const (
+	GRID_SIZE          = 15 // Rows and columns of the classic board
+	ARM_LENGTH         = 13 // Track squares every quadrant owns
+	HOME_COLUMN_LENGTH = 6  // Squares of a home column, the finish included
+)
+
+// LayoutColors are the colors of the quadrants of the ring shaped layouts, in turn order
+var LayoutColors = []string{"RED", "GREEN", "YELLOW", "BLUE", "ORANGE", "PURPLE"}
+
 var QuadrantsColors = map[string]string{
 	"QUADRANT_1": "RED",
 	"QUADRANT_2": "GREEN",
@@ -140,6 +189,14 @@ var QuadrantsPaths = map[string][]int{
 	},
 }
 
+// QuadrantsTeams pairs the opposite quadrants in team games
+var QuadrantsTeams = map[string]string{
+	"QUADRANT_1": "TEAM_1",
+	"QUADRANT_3": "TEAM_1",
+	"QUADRANT_2": "TEAM_2",
+	"QUADRANT_4": "TEAM_2",
+}
+
 var PLAYERS_REQUIRED_TO_START_GAME = []int{2, 4}
 
 const (
@@ -160,7 +217,79 @@ type RakeAmountType string
 
 var AUTO_PLAY_TIMER = 5
 
+// Boards are played with 1 to 4 pawns per quadrant, 4 being the classic game
+const (
+	MIN_PAWNS_PER_QUADRANT = 1
+	MAX_PAWNS_PER_QUADRANT = 4
+)
+
+// COUNTDOWN_INTERVAL is how often timed boards broadcast the time left
+var COUNTDOWN_INTERVAL = 10 * time.Second
+
 const (
 	FIXED      RakeAmountType = "FIXED"
 	PERCENTAGE RakeAmountType = "PERCENTAGE"
 )
+
+// StartOrder selects how the quadrant playing first is picked when a game starts
+type StartOrder string
+
+const (
+	START_DICE_OFF StartOrder = "DICE_OFF" // Every player rolls, the highest starts and ties roll again
+	START_RANDOM   StartOrder = "RANDOM"   // A player is drawn at random
+	START_FIXED    StartOrder = "FIXED"    // The first occupied quadrant starts
+)
+
+// MoveErrorCode tells a player why the server rejected a pawn move
+type MoveErrorCode string
+
+const (
+	MOVE_NOT_YOUR_QUADRANT MoveErrorCode = "NOT_YOUR_QUADRANT" // The quadrant of the move is not the sender's
+	MOVE_NOT_YOUR_TURN     MoveErrorCode = "NOT_YOUR_TURN"     // Another quadrant has to move
+	MOVE_NOT_EXPECTED      MoveErrorCode = "MOVE_NOT_EXPECTED" // No roll is waiting for a move
+	MOVE_INVALID_STEPS     MoveErrorCode = "INVALID_STEPS"     // The steps do not match the rolled dice
+	MOVE_DIE_USED          MoveErrorCode = "DIE_USED"          // The die was already moved with
+	MOVE_UNKNOWN_PAWN      MoveErrorCode = "UNKNOWN_PAWN"      // The pawn does not belong to the quadrant
+	MOVE_PAWN_NOT_MOVABLE  MoveErrorCode = "PAWN_NOT_MOVABLE"  // The pawn is not in the movable pawns of the roll
+	MOVE_GAME_OVER         MoveErrorCode = "GAME_OVER"
+)
+
+// BotDifficulty selects the strategy a bot uses to pick its moves
+type BotDifficulty string
+
+const (
+	BOT_EASY   BotDifficulty = "EASY"   // Picks any movable pawn
+	BOT_MEDIUM BotDifficulty = "MEDIUM" // Picks the best position right after its move
+	BOT_HARD   BotDifficulty = "HARD"   // Searches the next rolls of every player
+)
+
+// Expectiminimax depth, in rolls, of the engine backing each use
+var (
+	BOT_MEDIUM_SEARCH_DEPTH = 1
+	BOT_HARD_SEARCH_DEPTH   = 3
+	AUTO_PLAY_SEARCH_DEPTH  = 2
+	HINT_SEARCH_DEPTH       = 2
+)
+
+// WIN_PROBABILITY_ROLLOUTS is the number of random continuations simulated to estimate the win probabilities
+var WIN_PROBABILITY_ROLLOUTS = 3000
+
+// WIN_PROBABILITY_DELAY is how long a requested estimate waits, the moves played meanwhile are estimated together
+const WIN_PROBABILITY_DELAY = time.Second
+
+// BOT_THINK_TIME is how long a bot waits before answering a prompt, so its moves can be followed on the clients
+var BOT_THINK_TIME = 1500 * time.Millisecond
+
+// Anti-cheat analysis of the finished cash boards
+var (
+	ANTICHEAT_INTERVAL           = time.Minute            // How often finished boards are looked for
+	ANTICHEAT_BATCH_SIZE         = int64(50)              // Boards analyzed per run
+	ANTICHEAT_FLAG_SCORE         = 50                     // Risk score from which a board is flagged for review
+	ANTICHEAT_MIN_OPPORTUNITIES  = 3                      // Capture opportunities against an opponent before declines count
+	ANTICHEAT_DECLINE_RATE       = 0.75                   // Share of declined captures against an opponent that is suspicious
+	ANTICHEAT_MIN_REACTIONS      = 10                     // Moves of a player before its reaction times count
+	ANTICHEAT_FAST_REACTION      = 300 * time.Millisecond // Median reaction time below which moves look automated
+	ANTICHEAT_UNIFORM_VARIATION  = 0.1                    // Coefficient of variation of reaction times below which moves look automated
+	ANTICHEAT_CO_SEATING_WINDOW  = 30 * 24 * time.Hour    // How far back co-seating is counted
+	ANTICHEAT_CO_SEATING_ALLOWED = 5                      // Cash boards two players can share in the window before it is suspicious
+)
@@ -1,29 +1,52 @@
 package ludo
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"ludo/anticheat"
 	"ludo/board"
+	"ludo/bot"
 	"ludo/ludo_board_constants"
 	"ludo/quadrant"
+	"ludo/rules"
 	"messaging/common"
+	"metagame/gameserver/config"
 	"time"
 
 	"ludo/pawn"
 
 	"reflect"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// GAME_NAME is the game the service is registered as, and the game query parameter of its connections
+const GAME_NAME = ludo_board_constants.GAME
+
 var BoardInstances map[string]*board.Board = make(map[string]*board.Board)
 
+// BoardBots holds the bots seated on each board
+var BoardBots map[string][]*bot.Bot = make(map[string][]*bot.Bot)
+
+// boardsMu guards BoardInstances and BoardBots, the board management goroutine changes them while the players connect
+var boardsMu sync.RWMutex
+
+// draining is set once the server shuts down, no board is created from then on
+var draining atomic.Bool
+
 type BoardConfig struct {
 	boardId        string
 	playerCount    int
 	rakeAmountType ludo_board_constants.RakeAmountType
 	amount         int
+	ruleSet        rules.RuleSet
+	pawns          int // Pawns per quadrant
+	startOrder     ludo_board_constants.StartOrder
 }
 
 type LudoGameService struct {
@@ -39,17 +62,29 @@ func (gs *LudoGameService) ProcessMessage(boardId string, playerId string, messa
 
 	if len(eventParts) != 2 {
 		log.Printf("Invalid message %s from player %s", socketMessage.GetEventName(), playerId)
-		return fmt.Errorf("invalid event name format: %s", socketMessage.GetEventName())
+		return common.NewSocketError(common.INVALID_MESSAGE, fmt.Sprintf("invalid event name format: %s", socketMessage.GetEventName()))
 	}
 
 	className := eventParts[0]
 	methodName := eventParts[1]
 
-	board, exists := BoardInstances[boardId]
+	board := gs.GetBoard(boardId)
 
-	if !exists {
+	if board == nil {
 		// log.Printf("Game instance not found for room ID: %s", boardId)
-		return fmt.Errorf("game instance not found for room ID: %s", boardId)
+		return common.NewSocketError(common.NOT_FOUND, fmt.Sprintf("game instance not found for room ID: %s", boardId))
+	}
+
+	board.Lock()
+	defer board.Unlock()
+
+	// A snapshot can be asked at any time, it is not a move the board waits for
+	if methodName == string(ludo_board_constants.SNAPSHOT) {
+		return board.SendSnapshot(playerId, socketMessage.GetRequestId())
+	}
+
+	if board.IsDrained() {
+		return common.NewSocketError(common.SERVER_DRAINING, fmt.Sprintf("board %s is frozen while the server shuts down", boardId))
 	}
 
 	playerObj := board.GetPlayerByPlayerId(playerId)
@@ -62,19 +97,21 @@ func (gs *LudoGameService) ProcessMessage(boardId string, playerId string, messa
 	if expectedMessage != nil {
 		obj := *expectedMessage
 		if obj.EventName != socketMessage.GetEventName() {
-			err := fmt.Errorf("invalid event name : %s", socketMessage.GetEventName())
+			err := common.NewSocketError(common.INVALID_TURN, fmt.Sprintf("invalid event name : %s", socketMessage.GetEventName()))
 			log.Printf("Error: %s", err)
+			rejectPawnMove(board, methodName, playerId, rawBytes, ludo_board_constants.MOVE_NOT_EXPECTED, err)
 			return err
 		}
 
 		if obj.PlayerId != playerId {
-			err := fmt.Errorf("Invalid player %s, expected message from player %s", playerId, obj.PlayerId)
+			err := common.NewSocketError(common.INVALID_TURN, fmt.Sprintf("Invalid player %s, expected message from player %s", playerId, obj.PlayerId))
 			log.Printf("Error: %s", err)
+			rejectPawnMove(board, methodName, playerId, rawBytes, ludo_board_constants.MOVE_NOT_YOUR_TURN, err)
 			return err
 		}
 
 		if obj.Quadrant != playerObj.GetQuadrant() {
-			err := fmt.Errorf("Invalid quadrant %s from player %s", playerObj.GetQuadrant(), obj.Quadrant)
+			err := common.NewSocketError(common.INVALID_TURN, fmt.Sprintf("Invalid quadrant %s from player %s", playerObj.GetQuadrant(), obj.Quadrant))
 			log.Printf("Error: %s", err)
 			return err
 		}
@@ -111,6 +148,7 @@ func (gs *LudoGameService) ProcessMessage(boardId string, playerId string, messa
 		if err != nil {
 			log.Printf("Error %s when parsing pawn move message", err)
 		}
+		args = append(args, reflect.ValueOf(playerId))
 		args = append(args, reflect.ValueOf(pawnMoveMessageObject).Elem())
 	case string(ludo_board_constants.DICEROLL):
 		args = append(args, reflect.ValueOf(playerId))
@@ -121,30 +159,55 @@ func (gs *LudoGameService) ProcessMessage(boardId string, playerId string, messa
 	method := reflect.ValueOf(instance).MethodByName(methodName)
 
 	if !method.IsValid() {
-		return fmt.Errorf("method %s not found on class %s", methodName, className)
+		return common.NewSocketError(common.INVALID_MESSAGE, fmt.Sprintf("method %s not found on class %s", methodName, className))
 	}
 
-	method.Call(args)
+	results := method.Call(args)
+
+	// The error the board returns goes back to the player who sent the message
+	if len(results) > 0 {
+		if err, ok := results[len(results)-1].Interface().(error); ok && err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
+// rejectPawnMove tells a player sending a pawn move out of turn that it was rejected
+func rejectPawnMove(b *board.Board, methodName string, playerId string, rawBytes []byte, code ludo_board_constants.MoveErrorCode, reason error) {
+	if methodName != string(ludo_board_constants.MOVE_PAWN) || b.GetPlayerByPlayerId(playerId) == nil {
+		return
+	}
+
+	pawnMoveMessage, err := (&pawn.PawnMoveMessage{}).ToObject(string(rawBytes))
+	if err != nil {
+		log.Printf("Error %s when parsing pawn move message", err)
+		return
+	}
+
+	b.RejectPawnMove(playerId, *pawnMoveMessage.(*pawn.PawnMoveMessage), code, reason)
+}
+
 func (gs *LudoGameService) AddPlayer(boardId string, playerId string, name string, walletAddress string) error {
 	// Print roomId, playerId and name
 	// fmt.Println(boardId, playerId, name)
 
-	boardInstance, exists := BoardInstances[boardId]
+	boardInstance := gs.GetBoard(boardId)
 
 	// log.Println("AddPlayer called with boardId: ", boardId)
 
-	if !exists {
-		return fmt.Errorf("game instance not found for room ID: %s", boardId)
+	if boardInstance == nil {
+		return common.NewSocketError(common.NOT_FOUND, fmt.Sprintf("game instance not found for room ID: %s", boardId))
 	}
 
+	boardInstance.Lock()
+	defer boardInstance.Unlock()
+
 	err := boardInstance.AddPlayer(playerId, name, walletAddress)
 
 	if err != nil {
-		return fmt.Errorf("error adding player to board: %v", err)
+		return fmt.Errorf("error adding player to board: %w", err)
 	}
 
 	return nil
@@ -152,13 +215,16 @@ func (gs *LudoGameService) AddPlayer(boardId string, playerId string, name strin
 
 func (gs *LudoGameService) HandleDisconnection(boardId string, playerId string) error {
 
-	boardInstance, exists := BoardInstances[boardId]
+	boardInstance := gs.GetBoard(boardId)
 
-	if !exists {
+	if boardInstance == nil {
 		// log.Printf("Game instance not found for room ID: %s", boardId)
 		return fmt.Errorf("game instance not found for room ID: %s", boardId)
 	}
 
+	boardInstance.Lock()
+	defer boardInstance.Unlock()
+
 	error := boardInstance.HandleDisconnection(playerId)
 
 	if error != nil {
@@ -196,14 +262,21 @@ func (gs *LudoGameService) CreateFourPlayerEmptyBoardInstances() error {
 func (gs *LudoGameService) createEmptyBoardInstances(playerCount int) error {
 	// log.Printf("Creating empty board instances for %d player count", playerCount)
 
+	boardsMu.Lock()
+	defer boardsMu.Unlock()
+
 	// Count existing waiting boards for this player count
 	waitingBoardCount := 0
 	existingBoards := make(map[string]*board.Board)
 
 	for id, board := range BoardInstances {
-		if board.GetBoardStatus() == ludo_board_constants.WAITING &&
+		board.Lock()
+		isEmpty := board.GetBoardStatus() == ludo_board_constants.WAITING &&
 			board.GetMaxPlayers() == playerCount &&
-			len(board.GetPlayers()) == 0 {
+			len(board.GetPlayers()) == 0
+		board.Unlock()
+
+		if isEmpty {
 			waitingBoardCount++
 			existingBoards[id] = board
 			if waitingBoardCount >= 6 {
@@ -230,9 +303,16 @@ func (gs *LudoGameService) createEmptyBoardInstances(playerCount int) error {
 			playerCount:    playerCount,
 			rakeAmountType: rakeAmountType,
 			amount:         amount,
+			ruleSet:        getRuleSet(playerCount),
+			pawns:          getPawnsPerQuadrant(),
+			startOrder:     getStartOrder(),
 		}
 
-		newBoard := gs.createBoard(newBoardConfig)
+		newBoard, err := gs.createBoard(newBoardConfig)
+		if err != nil {
+			log.Printf("Skipping the board for %d players: %v", playerCount, err)
+			continue
+		}
 
 		BoardInstances[boardId] = newBoard
 		existingBoards[boardId] = newBoard
@@ -243,14 +323,62 @@ func (gs *LudoGameService) createEmptyBoardInstances(playerCount int) error {
 	return nil
 }
 
-func (gs *LudoGameService) createBoard(boardConfig BoardConfig) *board.Board {
+// getRuleSet returns the configured house rules for new boards, the classic rules when the configuration is invalid.
+// Teams need four players, so they are left out of the rules of smaller boards
+func getRuleSet(playerCount int) rules.RuleSet {
+	name := config.GetConfig().RuleSet
+
+	ruleSet, err := rules.GetRuleSet(name)
+	if err == nil {
+		err = ruleSet.Validate()
+	}
+
+	if err != nil {
+		log.Printf("Invalid rule set %s, using %s: %v", name, rules.CLASSIC, err)
+		return rules.CLASSIC_RULES
+	}
+
+	if ruleSet.Teams && playerCount != 4 {
+		ruleSet.Teams = false
+		ruleSet.PartnerRolls = false
+	}
+
+	return ruleSet
+}
+
+// getStartOrder returns the configured start order of new boards, a dice-off when it is unknown
+func getStartOrder() ludo_board_constants.StartOrder {
+	startOrder := ludo_board_constants.StartOrder(config.GetConfig().StartOrder)
+
+	switch startOrder {
+	case ludo_board_constants.START_DICE_OFF, ludo_board_constants.START_RANDOM, ludo_board_constants.START_FIXED:
+		return startOrder
+	}
+
+	log.Printf("Invalid start order %s, using %s", startOrder, ludo_board_constants.START_DICE_OFF)
+	return ludo_board_constants.START_DICE_OFF
+}
+
+// getPawnsPerQuadrant returns the configured number of pawns per quadrant for new boards, 4 when it is out of range
+func getPawnsPerQuadrant() int {
+	pawns := config.GetConfig().PawnsPerQuadrant
+
+	if pawns < ludo_board_constants.MIN_PAWNS_PER_QUADRANT || pawns > ludo_board_constants.MAX_PAWNS_PER_QUADRANT {
+		log.Printf("Invalid number of pawns per quadrant %d, using %d", pawns, ludo_board_constants.MAX_PAWNS_PER_QUADRANT)
+		return ludo_board_constants.MAX_PAWNS_PER_QUADRANT
+	}
+
+	return pawns
+}
+
+func (gs *LudoGameService) createBoard(boardConfig BoardConfig) (*board.Board, error) {
 
 	boardId := boardConfig.boardId
 	playerCount := boardConfig.playerCount
 	rakeAmountType := boardConfig.rakeAmountType
 	amount := boardConfig.amount
 
-	newBoard := board.NewBoard(
+	newBoard, err := board.NewBoard(
 		boardId,
 		playerCount,
 		ludo_board_constants.AUTO_PLAY,
@@ -258,11 +386,17 @@ func (gs *LudoGameService) createBoard(boardConfig BoardConfig) *board.Board {
 		int(ludo_board_constants.RAKE_AMOUNT[rakeAmountType]),
 		rakeAmountType,
 		ludo_board_constants.AUTO_PLAY_TIMER,
+		boardConfig.ruleSet,
+		boardConfig.pawns,
 	)
+	if err != nil {
+		return nil, err
+	}
 	log.Printf("Board created with ID: %s and players: %d", boardId, newBoard.GetMaxPlayers())
 	newBoard.SetTicketAmount(amount)
+	newBoard.SetStartOrder(boardConfig.startOrder)
 
-	return newBoard
+	return newBoard, nil
 
 }
 
@@ -275,9 +409,16 @@ func (gs *LudoGameService) CreateEmptyBoardInstances() error {
 		waitingBoards[amount] = make(map[string]*board.Board)
 	}
 
+	boardsMu.Lock()
+	defer boardsMu.Unlock()
+
 	// Count waiting boards by ticket amount
 	for id, board := range BoardInstances {
-		if board.GetBoardStatus() == ludo_board_constants.WAITING && len(board.GetPlayers()) == 0 {
+		board.Lock()
+		isEmpty := board.GetBoardStatus() == ludo_board_constants.WAITING && len(board.GetPlayers()) == 0
+		board.Unlock()
+
+		if isEmpty {
 			ticketAmount := board.GetTicketAmount()
 			if _, exists := waitingBoards[ticketAmount]; exists {
 				waitingBoards[ticketAmount][id] = board
@@ -296,8 +437,14 @@ func (gs *LudoGameService) CreateEmptyBoardInstances() error {
 					rakeAmountType = ludo_board_constants.PERCENTAGE
 				}
 
-				newBoard := board.NewBoard(boardId, playerCount, ludo_board_constants.AUTO_PLAY, amount, int(ludo_board_constants.RAKE_AMOUNT[rakeAmountType]), rakeAmountType, ludo_board_constants.AUTO_PLAY_TIMER)
+				newBoard, err := board.NewBoard(boardId, playerCount, ludo_board_constants.AUTO_PLAY, amount, int(ludo_board_constants.RAKE_AMOUNT[rakeAmountType]), rakeAmountType, ludo_board_constants.AUTO_PLAY_TIMER, getRuleSet(playerCount), getPawnsPerQuadrant())
+				if err != nil {
+					// The layout fails the same way for every board, the next refill tries again
+					log.Printf("Skipping the boards for %d players: %v", playerCount, err)
+					break
+				}
 				newBoard.SetTicketAmount(amount)
+				newBoard.SetStartOrder(getStartOrder())
 
 				BoardInstances[boardId] = newBoard
 				waitingBoards[amount][boardId] = newBoard
@@ -315,13 +462,24 @@ func (gs *LudoGameService) CreateEmptyBoardInstances() error {
 func (s *LudoGameService) cleanupAndCreateBoards() error {
 	// log.Println("Cleaning up finished boards and creating new empty boards")
 
+	if draining.Load() {
+		return nil
+	}
+
 	// Remove finished boards
+	boardsMu.Lock()
 	for id, board := range BoardInstances {
-		if board.GetBoardStatus() == ludo_board_constants.FINISHED && board.GetBoardStatus() == ludo_board_constants.DISCARDED {
+		board.Lock()
+		status := board.GetBoardStatus()
+		board.Unlock()
+
+		if status == ludo_board_constants.FINISHED || status == ludo_board_constants.DISCARDED {
 			// log.Printf("Removing finished board: %s", id)
 			delete(BoardInstances, id)
 		}
 	}
+	boardsMu.Unlock()
+
 	// Create new empty boards
 	if err := s.CreateTwoPlayerEmptyBoardInstances(); err != nil {
 		log.Printf("Error creating 2 player empty board instances: %v", err)
@@ -343,25 +501,164 @@ func (s *LudoGameService) StartBoardManagement() {
 		return
 	}
 
+	// A single goroutine manages the boards, the seating of the bots and the anti-cheat analysis, so none of them
+	// runs alongside another. The boards it shares with the players are read and changed under boardsMu.
 	go func() {
-		ticker := time.NewTicker(30 * time.Second)
-
-		defer ticker.Stop()
-		for range ticker.C {
-			// log.Println("Starting board management routine")
-			// log.Println("Running board cleanup and creation")
-			if err := s.cleanupAndCreateBoards(); err != nil {
-				log.Printf("Error in board management: %v", err)
-				break
+		boardTicker := time.NewTicker(30 * time.Second)
+		botTicker := time.NewTicker(5 * time.Second)
+		anticheatTicker := time.NewTicker(ludo_board_constants.ANTICHEAT_INTERVAL)
+
+		defer boardTicker.Stop()
+		defer botTicker.Stop()
+		defer anticheatTicker.Stop()
+
+		for {
+			select {
+			case <-boardTicker.C:
+				// log.Println("Starting board management routine")
+				// log.Println("Running board cleanup and creation")
+				if err := s.cleanupAndCreateBoards(); err != nil {
+					log.Printf("Error in board management: %v", err)
+					return
+				}
+			case <-botTicker.C:
+				s.releaseBots()
+				s.seatBots()
+			case <-anticheatTicker.C:
+				// The analysis only reads the finished boards stored in the database
+				anticheat.AnalyzeFinishedBoards()
 			}
 		}
 	}()
 }
 
+// seatBots fills the empty seats of WAITING boards whose players have waited longer than the configured time,
+// on the ticket amounts bots are enabled for only
+func (s *LudoGameService) seatBots() {
+	cfg := config.GetConfig()
+
+	if cfg.BotSeatWaitSeconds <= 0 || len(cfg.BotTicketAmounts) == 0 {
+		return
+	}
+
+	wait := time.Duration(cfg.BotSeatWaitSeconds) * time.Second
+
+	for _, boardInstance := range s.GetBoardList() {
+		boardInstance.Lock()
+		seated := seatBotsOn(boardInstance, wait, cfg.BotTicketAmounts, ludo_board_constants.BotDifficulty(cfg.BotDifficulty), s)
+		boardInstance.Unlock()
+
+		if len(seated) > 0 {
+			boardsMu.Lock()
+			BoardBots[boardInstance.GetID()] = append(BoardBots[boardInstance.GetID()], seated...)
+			boardsMu.Unlock()
+		}
+	}
+}
+
+// seatBotsOn fills the empty seats of the board, which must be locked, if its players have waited long enough and
+// its ticket amount is one of the tiers bots are enabled for. Returns the bots seated.
+func seatBotsOn(boardInstance *board.Board, wait time.Duration, ticketAmounts []int, difficulty ludo_board_constants.BotDifficulty, gameService common.GameService) []*bot.Bot {
+	seated := []*bot.Bot{}
+	boardId := boardInstance.GetID()
+
+	if boardInstance.GetBoardStatus() != ludo_board_constants.WAITING {
+		return seated
+	}
+
+	if !slices.Contains(ticketAmounts, boardInstance.GetTicketAmount()) {
+		return seated
+	}
+
+	playerCount := len(boardInstance.GetPlayers())
+
+	if playerCount == 0 || playerCount >= boardInstance.GetMaxPlayers() {
+		return seated
+	}
+
+	if time.Since(boardInstance.GetWaitingSince()) < wait {
+		return seated
+	}
+
+	for len(boardInstance.GetPlayers()) < boardInstance.GetMaxPlayers() {
+		newBot := bot.NewBot(boardId, difficulty, gameService)
+		newBot.Join()
+
+		if err := boardInstance.AddBot(newBot.GetId(), newBot.GetName()); err != nil {
+			log.Printf("Error seating bot on board %s: %v", boardId, err)
+			newBot.Leave()
+			break
+		}
+
+		seated = append(seated, newBot)
+		log.Printf("Bot %s seated on board %s", newBot.GetId(), boardId)
+	}
+
+	return seated
+}
+
+// releaseBots detaches the bots of boards that are over, and of WAITING boards that only bots are left on
+func (s *LudoGameService) releaseBots() {
+	boardsMu.Lock()
+	defer boardsMu.Unlock()
+
+	for boardId, bots := range BoardBots {
+		if boardInstance, exists := BoardInstances[boardId]; exists {
+			boardInstance.Lock()
+			released := releaseBotsOf(boardInstance, bots)
+			boardInstance.Unlock()
+
+			if !released {
+				continue
+			}
+		} else {
+			for _, b := range bots {
+				b.Leave()
+			}
+		}
+
+		delete(BoardBots, boardId)
+	}
+}
+
+// releaseBotsOf detaches the bots of the board, which must be locked, unless they are still needed on it.
+// Returns true when the bots were released.
+func releaseBotsOf(boardInstance *board.Board, bots []*bot.Bot) bool {
+	if boardInstance.GetBoardStatus() == ludo_board_constants.PLAYING {
+		return false
+	}
+
+	isWaiting := boardInstance.GetBoardStatus() == ludo_board_constants.WAITING
+
+	if isWaiting && len(boardInstance.GetPlayers()) > boardInstance.GetBotCount() {
+		return false
+	}
+
+	for _, b := range bots {
+		b.Leave()
+		if isWaiting {
+			boardInstance.RemovePlayer(b.GetId())
+		}
+	}
+
+	return true
+}
+
+// GetBoard returns the board instance with the given id, nil if there is none
+func (gs *LudoGameService) GetBoard(boardId string) *board.Board {
+	boardsMu.RLock()
+	defer boardsMu.RUnlock()
+
+	return BoardInstances[boardId]
+}
+
 func (gs *LudoGameService) GetBoardList() []*board.Board {
 
 	// log.Println("Getting running board lists")
 
+	boardsMu.RLock()
+	defer boardsMu.RUnlock()
+
 	var boardLists []*board.Board
 
 	for _, board := range BoardInstances {
@@ -370,3 +667,80 @@ func (gs *LudoGameService) GetBoardList() []*board.Board {
 
 	return boardLists
 }
+
+// ListBoards returns the boards waiting for players or being played, as the lobby lists them. The boards closed by
+// the shutdown are listed UNAVAILABLE.
+func (gs *LudoGameService) ListBoards() []common.BoardSummary {
+	boards := []common.BoardSummary{}
+
+	for _, b := range gs.GetBoardList() {
+		b.Lock()
+		if isListed(b.GetBoardStatus()) {
+			boards = append(boards, summarize(b))
+		}
+		b.Unlock()
+	}
+
+	return boards
+}
+
+// summarize returns the lobby listing of the board, which must be locked
+func summarize(b *board.Board) common.BoardSummary {
+	players := []common.PlayerSummary{}
+	for _, p := range b.GetPlayers() {
+		players = append(players, common.PlayerSummary{
+			PlayerId: p.ID,
+			Name:     p.Name,
+			IsBot:    p.IsBotPlayer(),
+		})
+	}
+
+	return common.BoardSummary{
+		Game:                       GAME_NAME,
+		BoardId:                    b.GetID(),
+		Players:                    players,
+		PlayersRequiredToStartGame: b.GetMaxPlayers(),
+		Status:                     string(b.GetBoardStatus()),
+		TicketAmount:               b.GetTicketAmount(),
+		Settings: map[string]interface{}{
+			"autoPlay":         b.GetAutoPlay(),
+			"ruleSet":          b.GetRuleSet().Name,
+			"pawnsPerQuadrant": b.GetPawnsPerQuadrant(),
+			"layout":           b.GetGeometry().Layout,
+		},
+	}
+}
+
+// isListed reports whether the lobby lists the boards with the status
+func isListed(status ludo_board_constants.BoardStatus) bool {
+	return status == ludo_board_constants.WAITING || status == ludo_board_constants.PLAYING || status == ludo_board_constants.UNAVAILABLE
+}
+
+// Drain stops creating boards when the server shuts down. The WAITING boards are closed to new players and the
+// boards being played are frozen and their state stored.
+func (gs *LudoGameService) Drain() error {
+	draining.Store(true)
+
+	boardsMu.RLock()
+	defer boardsMu.RUnlock()
+
+	errs := []error{}
+
+	for boardId, boardInstance := range BoardInstances {
+		// The bots stop first, so none of their moves lands after the state is stored
+		for _, b := range BoardBots[boardId] {
+			b.Leave()
+		}
+
+		boardInstance.Lock()
+		err := boardInstance.Drain()
+		boardInstance.Unlock()
+
+		if err != nil {
+			log.Printf("Error draining board %s: %v", boardInstance.GetID(), err)
+			errs = append(errs, fmt.Errorf("board %s: %w", boardInstance.GetID(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
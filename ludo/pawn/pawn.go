@@ -139,7 +139,8 @@ func (p *Pawn) Move(steps int) ValidationError {
 
 }
 
-// IsValidMove checks if moving the specified steps is allowed
+// IsValidMove checks if moving the specified steps is allowed.
+// Blockades are checked by the rules of the board, see rules.State.LegalMoves.
 func (p *Pawn) IsValidMove(steps int) bool {
 	// fmt.Printf("Validating move for pawn %s: steps=%d, currentPosition=%v, status=%v\n", p.name, steps, p.currentPosition, p.status)
 
@@ -204,22 +205,6 @@ func (p *Pawn) GetNextPosition(steps int) int {
 	return p.path[nextIndex]
 }
 
-// IsBlocked checks if the pawn's next move is blocked by other pawns
-// Parameters:
-//   - otherPawns: Slice of other pawns to check for collision
-//
-// Returns:
-//   - bool: true if path is blocked, false if clear
-func (p *Pawn) IsBlocked(otherPawns []*Pawn) bool {
-	nextPos := p.GetNextPosition(1)
-	for _, other := range otherPawns {
-		if other != p && other.GetPosition() == &nextPos {
-			return true
-		}
-	}
-	return false
-}
-
 // getCurrentPathIndex finds the current position's index in the path
 // Returns:
 //   - int: Index in path array, -1 if not found
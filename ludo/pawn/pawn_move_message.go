@@ -12,15 +12,17 @@ type PawnMoveMessage struct {
 	quadrant  string
 	pawn      string
 	steps     int
+	die       int // Index of the die the move uses in two-dice games
 }
 
 // NewPawnMoveMessage creates a new PawnMoveMessage.
-func NewPawnMoveMessage(eventName string, quadrant string, pawn string, steps int) *PawnMoveMessage {
+func NewPawnMoveMessage(eventName string, quadrant string, pawn string, steps int, die int) *PawnMoveMessage {
 	return &PawnMoveMessage{
 		eventName: eventName,
 		quadrant:  quadrant,
 		pawn:      pawn,
 		steps:     steps,
+		die:       die,
 	}
 }
 
@@ -29,6 +31,7 @@ func (m *PawnMoveMessage) GetPawnMoveMessage() PawnMoveMessage {
 	return PawnMoveMessage{
 		pawn:      m.pawn,
 		steps:     m.steps,
+		die:       m.die,
 		quadrant:  m.quadrant,
 		eventName: m.eventName,
 	}
@@ -54,6 +57,11 @@ func (m *PawnMoveMessage) GetSteps() int {
 	return m.steps
 }
 
+// GetDie returns the index of the die the PawnMoveMessage uses.
+func (m *PawnMoveMessage) GetDie() int {
+	return m.die
+}
+
 // ToJSON converts the PawnMoveMessage to a JSON string.
 func (m *PawnMoveMessage) ToJSON() (string, error) {
 	jsonData, err := json.Marshal(&struct {
@@ -61,11 +69,13 @@ func (m *PawnMoveMessage) ToJSON() (string, error) {
 		Quadrant  string `json:"quadrant"`
 		Pawn      string `json:"pawn"`
 		Steps     int    `json:"steps"`
+		Die       int    `json:"die"`
 	}{
 		EventName: m.eventName,
 		Quadrant:  m.quadrant,
 		Pawn:      m.pawn,
 		Steps:     m.steps,
+		Die:       m.die,
 	})
 	if err != nil {
 		return "", err
@@ -80,6 +90,7 @@ func (m *PawnMoveMessage) ToObject(data string) (common.Message, error) {
 		Quadrant  string `json:"quadrant"`
 		Pawn      string `json:"pawn"`
 		Steps     int    `json:"steps"`
+		Die       int    `json:"die"`
 	}
 
 	err := json.Unmarshal([]byte(data), &intermediate)
@@ -93,5 +104,6 @@ func (m *PawnMoveMessage) ToObject(data string) (common.Message, error) {
 		quadrant:  intermediate.Quadrant,
 		pawn:      intermediate.Pawn,
 		steps:     intermediate.Steps,
+		die:       intermediate.Die,
 	}, nil
 }
@@ -0,0 +1,88 @@
+package pawn
+
+import (
+	"encoding/json"
+	"messaging/common"
+)
+
+// PawnMoveRejectedMessage tells the player who sent a pawn move why the server rejected it.
+// The turn does not advance, so MovablePawns lists the pawns the player can still pick.
+type PawnMoveRejectedMessage struct {
+	common.Message
+	eventName    string
+	quadrant     string
+	pawn         string
+	steps        int
+	code         string
+	reason       string
+	movablePawns []string
+	violations   int // Rejected moves of the player on the board so far
+}
+
+// NewPawnMoveRejectedMessage creates a new PawnMoveRejectedMessage.
+func NewPawnMoveRejectedMessage(eventName string, quadrant string, pawn string, steps int, code string, reason string, movablePawns []string, violations int) *PawnMoveRejectedMessage {
+	return &PawnMoveRejectedMessage{
+		eventName:    eventName,
+		quadrant:     quadrant,
+		pawn:         pawn,
+		steps:        steps,
+		code:         code,
+		reason:       reason,
+		movablePawns: movablePawns,
+		violations:   violations,
+	}
+}
+
+// GetCode returns the error code of the PawnMoveRejectedMessage.
+func (m *PawnMoveRejectedMessage) GetCode() string {
+	return m.code
+}
+
+// ToJSON converts the PawnMoveRejectedMessage to a JSON string.
+func (m *PawnMoveRejectedMessage) ToJSON() (string, error) {
+	jsonData, err := json.Marshal(&struct {
+		EventName    string   `json:"eventName"`
+		Quadrant     string   `json:"quadrant"`
+		Pawn         string   `json:"pawn"`
+		Steps        int      `json:"steps"`
+		Code         string   `json:"code"`
+		Reason       string   `json:"reason"`
+		MovablePawns []string `json:"movablePawns"`
+		Violations   int      `json:"violations"`
+	}{
+		EventName:    m.eventName,
+		Quadrant:     m.quadrant,
+		Pawn:         m.pawn,
+		Steps:        m.steps,
+		Code:         m.code,
+		Reason:       m.reason,
+		MovablePawns: m.movablePawns,
+		Violations:   m.violations,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+// ToObject converts a JSON string to a PawnMoveRejectedMessage.
+func (m *PawnMoveRejectedMessage) ToObject(data string) (common.Message, error) {
+	var intermediate struct {
+		EventName    string   `json:"eventName"`
+		Quadrant     string   `json:"quadrant"`
+		Pawn         string   `json:"pawn"`
+		Steps        int      `json:"steps"`
+		Code         string   `json:"code"`
+		Reason       string   `json:"reason"`
+		MovablePawns []string `json:"movablePawns"`
+		Violations   int      `json:"violations"`
+	}
+
+	err := json.Unmarshal([]byte(data), &intermediate)
+
+	if err != nil {
+		return &PawnMoveRejectedMessage{}, err
+	}
+
+	return NewPawnMoveRejectedMessage(intermediate.EventName, intermediate.Quadrant, intermediate.Pawn, intermediate.Steps, intermediate.Code, intermediate.Reason, intermediate.MovablePawns, intermediate.Violations), nil
+}
@@ -22,10 +22,14 @@ type PawnMovedMessage struct {
 	finalIndex       int
 	isAtHome         bool
 	quadrant         string
+	rolledBy         string // Quadrant of the player who rolled, another one than quadrant when a finished teammate rolled
 	capturedPawns    []string
 	responseCode     int
 	validationErrors []ValidationError
-	positions        []PawnPositions
+	sync             StateSync // Version of the board after the move and the pawns it moved, the captured ones included
+	path             []int     // Squares the pawn went through, the final one included
+	die              int       // Index of the die the move used
+	remainingDice    []int     // Indexes of the dice still to move with, in two-dice games
 }
 
 // NewPawnMovedMessage creates a new PawnMovedMessage.
@@ -60,6 +64,9 @@ func NewPawnMovedMessage(data map[string]interface{}) *PawnMovedMessage {
 	if v, ok := data["quadrant"].(string); ok {
 		msg.quadrant = v
 	}
+	if v, ok := data["rolledBy"].(string); ok {
+		msg.rolledBy = v
+	}
 	if v, ok := data["capturedPawns"].([]string); ok {
 		msg.capturedPawns = v
 	}
@@ -73,8 +80,17 @@ func NewPawnMovedMessage(data map[string]interface{}) *PawnMovedMessage {
 			msg.validationErrors = v
 		}
 	}
-	if v, ok := data["positions"].([]PawnPositions); ok {
-		msg.positions = v
+	if v, ok := data["sync"].(StateSync); ok {
+		msg.sync = v
+	}
+	if v, ok := data["path"].([]int); ok {
+		msg.path = v
+	}
+	if v, ok := data["die"].(int); ok {
+		msg.die = v
+	}
+	if v, ok := data["remainingDice"].([]int); ok {
+		msg.remainingDice = v
 	}
 	return msg
 }
@@ -86,6 +102,7 @@ func (m *PawnMovedMessage) ToJSON() (string, error) {
 		Pawn             string            `json:"pawn"`
 		Steps            int               `json:"steps"`
 		Quadrant         string            `json:"quadrant"`
+		RolledBy         string            `json:"rolledBy"`
 		ResponseCode     int               `json:"responseCode"`
 		InitialPosition  int               `json:"initialPosition"`
 		FinalPosition    int               `json:"finalPosition"`
@@ -94,12 +111,18 @@ func (m *PawnMovedMessage) ToJSON() (string, error) {
 		IsAtHome         bool              `json:"isAtHome"`
 		CapturedPawns    []string          `json:"capturedPawns"`
 		ValidationErrors []ValidationError `json:"validationErrors"`
-		Postions         []PawnPositions   `json:"positions"`
+		Version          int               `json:"version"`
+		Checksum         string            `json:"checksum"`
+		Changes          []PawnPositions   `json:"changes"`
+		Path             []int             `json:"path"`
+		Die              int               `json:"die"`
+		RemainingDice    []int             `json:"remainingDice,omitempty"`
 	}{
 		EventName:        m.eventName,
 		Pawn:             m.pawn,
 		Steps:            m.steps,
 		Quadrant:         m.quadrant,
+		RolledBy:         m.rolledBy,
 		ResponseCode:     m.responseCode,
 		InitialPosition:  m.initialPosition,
 		FinalPosition:    m.finalPosition,
@@ -108,7 +131,12 @@ func (m *PawnMovedMessage) ToJSON() (string, error) {
 		IsAtHome:         m.isAtHome,
 		CapturedPawns:    m.capturedPawns,
 		ValidationErrors: m.validationErrors,
-		Postions:         m.positions,
+		Version:          m.sync.Version,
+		Checksum:         m.sync.Checksum,
+		Changes:          m.sync.Changes,
+		Path:             m.path,
+		Die:              m.die,
+		RemainingDice:    m.remainingDice,
 	})
 	if err != nil {
 		return "", err
@@ -123,6 +151,7 @@ func (m *PawnMovedMessage) ToObject(data string) (common.Message, error) {
 		Pawn             string            `json:"pawn"`
 		Steps            int               `json:"steps"`
 		Quadrant         string            `json:"quadrant"`
+		RolledBy         string            `json:"rolledBy"`
 		ResponseCode     int               `json:"responseCode"`
 		InitialPosition  int               `json:"initialPosition"`
 		FinalPosition    int               `json:"finalPosition"`
@@ -131,6 +160,12 @@ func (m *PawnMovedMessage) ToObject(data string) (common.Message, error) {
 		IsAtHome         bool              `json:"isAtHome"`
 		CapturedPawns    []string          `json:"capturedPawns"`
 		ValidationErrors []ValidationError `json:"validationErrors"`
+		Version          int               `json:"version"`
+		Checksum         string            `json:"checksum"`
+		Changes          []PawnPositions   `json:"changes"`
+		Path             []int             `json:"path"`
+		Die              int               `json:"die"`
+		RemainingDice    []int             `json:"remainingDice,omitempty"`
 	}
 
 	err := json.Unmarshal([]byte(data), &intermediate)
@@ -144,6 +179,7 @@ func (m *PawnMovedMessage) ToObject(data string) (common.Message, error) {
 		pawn:             intermediate.Pawn,
 		steps:            intermediate.Steps,
 		quadrant:         intermediate.Quadrant,
+		rolledBy:         intermediate.RolledBy,
 		responseCode:     intermediate.ResponseCode,
 		initialPosition:  intermediate.InitialPosition,
 		finalPosition:    intermediate.FinalPosition,
@@ -152,6 +188,14 @@ func (m *PawnMovedMessage) ToObject(data string) (common.Message, error) {
 		isAtHome:         intermediate.IsAtHome,
 		capturedPawns:    intermediate.CapturedPawns,
 		validationErrors: intermediate.ValidationErrors,
+		sync: StateSync{
+			Version:  intermediate.Version,
+			Checksum: intermediate.Checksum,
+			Changes:  intermediate.Changes,
+		},
+		path:          intermediate.Path,
+		die:           intermediate.Die,
+		remainingDice: intermediate.RemainingDice,
 	}, nil
 }
 
@@ -163,6 +207,7 @@ type PawnPosition struct {
 type PawnPositions struct {
 	Quadrant      string         `json:"quadrant"`
 	PawnPositions []PawnPosition `json:"pawnPositions"`
+	Blockades     []int          `json:"blockades,omitempty"` // Positions where the quadrant's pawns bar the opponents
 }
 
 func NewPawnPosition(name string, currentPosition int) *PawnPosition {
@@ -0,0 +1,113 @@
+package pawn
+
+import (
+	"fmt"
+	"hash/crc32"
+	"slices"
+	"strings"
+)
+
+// StateSync is sent with the messages changing the board, so clients notice the events they missed.
+// Clients apply the changes to the positions they hold and compare the checksum of the result with the one of the
+// message, a client whose checksum does not match asks for a Board.Snapshot.
+type StateSync struct {
+	Version  int             // Version of the board state, incremented on every change of the game
+	Checksum string          // StateChecksum of the positions once the changes are applied
+	Changes  []PawnPositions // Pawns moved since the previous message, see DiffPositions
+}
+
+// DiffPositions returns the pawns whose position changed from previous to current, by quadrant.
+// A quadrant is in the changes when one of its pawns moved or its blockades changed, and its blockades replace the
+// previous ones then. Without previous positions every pawn is a change.
+func DiffPositions(previous []PawnPositions, current []PawnPositions) []PawnPositions {
+	changes := []PawnPositions{}
+
+	for _, quadrantPositions := range current {
+		before := findQuadrantPositions(previous, quadrantPositions.Quadrant)
+		if before == nil {
+			changes = append(changes, quadrantPositions)
+			continue
+		}
+
+		changed := PawnPositions{
+			Quadrant:      quadrantPositions.Quadrant,
+			PawnPositions: []PawnPosition{},
+			Blockades:     quadrantPositions.Blockades,
+		}
+		for _, position := range quadrantPositions.PawnPositions {
+			if !slices.Contains(before.PawnPositions, position) {
+				changed.PawnPositions = append(changed.PawnPositions, position)
+			}
+		}
+
+		if len(changed.PawnPositions) > 0 || !slices.Equal(before.Blockades, quadrantPositions.Blockades) {
+			changes = append(changes, changed)
+		}
+	}
+
+	return changes
+}
+
+func findQuadrantPositions(positions []PawnPositions, quadrant string) *PawnPositions {
+	for i := range positions {
+		if positions[i].Quadrant == quadrant {
+			return &positions[i]
+		}
+	}
+	return nil
+}
+
+// ApplyChanges returns the positions with the changes of a StateSync applied, the way clients keep the board in sync
+func ApplyChanges(positions []PawnPositions, changes []PawnPositions) []PawnPositions {
+	applied := make([]PawnPositions, 0, len(positions))
+	for _, quadrantPositions := range positions {
+		quadrantPositions.PawnPositions = slices.Clone(quadrantPositions.PawnPositions)
+		applied = append(applied, quadrantPositions)
+	}
+
+	for _, change := range changes {
+		current := findQuadrantPositions(applied, change.Quadrant)
+		if current == nil {
+			applied = append(applied, change)
+			continue
+		}
+
+		for _, position := range change.PawnPositions {
+			for i := range current.PawnPositions {
+				if current.PawnPositions[i].Name == position.Name {
+					current.PawnPositions[i] = position
+				}
+			}
+		}
+		current.Blockades = change.Blockades
+	}
+
+	return applied
+}
+
+// StateChecksum returns the CRC-32 (IEEE) of the canonical state of the pawns, as 8 hex digits.
+// The canonical state lists the quadrants in the order of the positions, each as
+// "quadrant:pawn=position,pawn=position;blockade,blockade|".
+func StateChecksum(positions []PawnPositions) string {
+	var canonical strings.Builder
+
+	for _, quadrantPositions := range positions {
+		canonical.WriteString(quadrantPositions.Quadrant + ":")
+		for i, position := range quadrantPositions.PawnPositions {
+			if i > 0 {
+				canonical.WriteString(",")
+			}
+			fmt.Fprintf(&canonical, "%s=%d", position.Name, position.CurrentPosition)
+		}
+		canonical.WriteString(";")
+		for i, blockade := range quadrantPositions.Blockades {
+			if i > 0 {
+				canonical.WriteString(",")
+			}
+			fmt.Fprintf(&canonical, "%d", blockade)
+		}
+		canonical.WriteString("|")
+	}
+
+	return fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(canonical.String())))
+}
@@ -0,0 +1,57 @@
+package pawn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func positionsOf(q1 []int, q2 []int, q1Blockades []int) []PawnPositions {
+	return []PawnPositions{
+		{Quadrant: "QUADRANT_1", PawnPositions: []PawnPosition{{"Q1_P1", q1[0]}, {"Q1_P2", q1[1]}}, Blockades: q1Blockades},
+		{Quadrant: "QUADRANT_2", PawnPositions: []PawnPosition{{"Q2_P1", q2[0]}, {"Q2_P2", q2[1]}}},
+	}
+}
+
+func TestDiffPositionsCarriesOnlyTheMovedPawns(t *testing.T) {
+	previous := positionsOf([]int{5, -1}, []int{9, -1}, nil)
+	current := positionsOf([]int{9, -1}, []int{-1, -1}, nil)
+
+	changes := DiffPositions(previous, current)
+
+	assert.Equal(t, []PawnPositions{
+		{Quadrant: "QUADRANT_1", PawnPositions: []PawnPosition{{"Q1_P1", 9}}},
+		{Quadrant: "QUADRANT_2", PawnPositions: []PawnPosition{{"Q2_P1", -1}}},
+	}, changes, "The move and the capture should be the only changes")
+	assert.Equal(t, current, ApplyChanges(previous, changes))
+	assert.Equal(t, StateChecksum(current), StateChecksum(ApplyChanges(previous, changes)))
+	assert.Equal(t, positionsOf([]int{5, -1}, []int{9, -1}, nil), previous, "Applying changes should not modify the positions it starts from")
+}
+
+func TestDiffPositionsReplacesTheBlockades(t *testing.T) {
+	previous := positionsOf([]int{7, 7}, []int{-1, -1}, []int{7})
+	current := positionsOf([]int{7, 10}, []int{-1, -1}, nil)
+
+	applied := ApplyChanges(previous, DiffPositions(previous, current))
+
+	assert.Empty(t, applied[0].Blockades, "A quadrant in the changes should replace its blockades")
+	assert.Equal(t, StateChecksum(current), StateChecksum(applied))
+}
+
+func TestStateChecksumDetectsAMissedMove(t *testing.T) {
+	first := positionsOf([]int{1, -1}, []int{-1, -1}, nil)
+	second := positionsOf([]int{4, -1}, []int{-1, -1}, nil)
+	third := positionsOf([]int{4, -1}, []int{2, -1}, nil)
+
+	// The client missed the second message and applies the third one on the first positions
+	stale := ApplyChanges(first, DiffPositions(second, third))
+
+	assert.NotEqual(t, StateChecksum(third), StateChecksum(stale))
+	assert.Len(t, StateChecksum(third), 8)
+}
+
+func TestDiffPositionsWithoutPreviousPositions(t *testing.T) {
+	current := positionsOf([]int{-1, -1}, []int{-1, -1}, nil)
+
+	assert.Equal(t, current, DiffPositions(nil, current), "The first message should carry every pawn")
+}
@@ -10,6 +10,7 @@ type Player struct {
 	ConnectionStatus        int    // 0: Disconnected, 1: Connected
 	BetId                   string // Bet ID for the player
 	WalletAddress           string // Wallet address for the player
+	IsBot                   bool   // Server-side bot filling an empty seat, never charged or paid
 }
 
 const (
@@ -56,6 +57,11 @@ func (p *Player) SetConnectionStatus(status int) {
 	p.ConnectionStatus = status
 }
 
+// IsBotPlayer reports whether the player is a server-side bot
+func (p *Player) IsBotPlayer() bool {
+	return p.IsBot
+}
+
 func (p *Player) IsConnected() bool {
 	return p.ConnectionStatus == PLAYER_CONNECTED
 }
@@ -7,6 +7,9 @@ type PlayerSchema struct {
 	PlayerID       string    `bson:"playerId" json:"playerId"`
 	Name           string    `bson:"name" json:"name"`
 	Quadrant       string    `bson:"quadrant" json:"quadrant"`
+	IsBot          bool      `bson:"isBot" json:"isBot"`
+	WalletAddress  string    `bson:"walletAddress,omitempty" json:"walletAddress,omitempty"`
+	IPAddress      string    `bson:"ipAddress,omitempty" json:"ipAddress,omitempty"` // IP the player connected from when taking the seat
 	JoinedAt       time.Time `bson:"joinedAt" json:"joinedAt"`
 	DisconnectedAt time.Time `bson:"disconnectedAt,omitempty" json:"disconnectedAt,omitempty"`
 	ReconnectedAt  time.Time `bson:"reconnectedAt,omitempty" json:"reconnectedAt,omitempty"`
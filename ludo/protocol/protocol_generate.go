@@ -0,0 +1,27 @@
+//go:build ignore
+
+package main
+
+import (
+	"log"
+	"ludo/protocol"
+	"os"
+)
+
+// Writes the JSON Schema and the AsyncAPI document of the socket messages, run with go generate
+func main() {
+	schema, err := protocol.GenerateJSONSchema()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(protocol.SCHEMA_FILE, append(schema, '\n'), 0644); err != nil {
+		log.Fatal(err)
+	}
+	asyncAPI, err := protocol.GenerateAsyncAPI()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(protocol.ASYNCAPI_FILE, append(asyncAPI, '\n'), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
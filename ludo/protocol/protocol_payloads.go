@@ -0,0 +1,223 @@
+package protocol
+
+import (
+	"ludo/board"
+	"ludo/pawn"
+	"ludo/rules"
+	"time"
+)
+
+// The payloads are the wire shape of the socket messages, as their ToJSON writes them
+
+type GameInitializePayload struct {
+	EventName                  string           `json:"eventName"`
+	SafePositions              []int            `json:"safePositions"`
+	Quadrants                  []board.Quadrant `json:"quadrants"`
+	AutoPlay                   bool             `json:"autoPlay"`
+	AutoPlayTimer              int              `json:"autoPlayTimer"`
+	PlayersRequiredToStartGame int              `json:"playersRequiredToStartGame"`
+	TicketAmount               int              `json:"ticketAmount"`
+	PlayerSelectingTheQuadrant board.Player     `json:"playerSelectingTheQuadrant"`
+	RuleSet                    rules.RuleSet    `json:"ruleSet"`
+	Grid                       board.Grid       `json:"grid"`
+}
+
+type SelectQuadrantPayload struct {
+	EventName    string   `json:"eventName"`
+	Quadrants    []string `json:"quadrants"`
+	ResponseCode int      `json:"responseCode"`
+}
+
+type QuadrantSelectPayload struct {
+	EventName string `json:"eventName"`
+	Quadrant  string `json:"quadrant"`
+	RequestId string `json:"requestId,omitempty"`
+}
+
+type BoardJoinedPayload struct {
+	EventName                  string                  `json:"eventName"`
+	Participants               []board.ParticipantInfo `json:"participants"`
+	PlayerSelectingTheQuadrant board.Player            `json:"playerSelectingTheQuadrant"`
+}
+
+type BoardWaitingPlayersPayload struct {
+	EventName               string         `json:"eventName"`
+	WaitingPlayers          []board.Player `json:"waitingPlayers"`
+	NewPlayer               board.Player   `json:"newPlayer"`
+	PlayerSelectingQuadrant board.Player   `json:"playerSelectingQuadrant"`
+}
+
+type BoardSelectingQuadrantPayload struct {
+	EventName string       `json:"eventName"`
+	Player    board.Player `json:"player"`
+}
+
+type BoardBetFailedPayload struct {
+	EventName string `json:"eventName"`
+	Message   string `json:"message"`
+}
+
+type GameCountdownPayload struct {
+	EventName        string    `json:"eventName"`
+	RemainingSeconds int       `json:"remainingSeconds"`
+	EndsAt           time.Time `json:"endsAt"`
+}
+
+type GameStartPayload struct {
+	EventName string `json:"eventName"`
+}
+
+type GameDiceOffPayload struct {
+	EventName string              `json:"eventName"`
+	Round     int                 `json:"round"`
+	Rolls     []board.DiceOffRoll `json:"rolls"`
+	Tied      []string            `json:"tied"`
+}
+
+type GameFirstTurnPayload struct {
+	EventName  string `json:"eventName"`
+	Quadrant   string `json:"quadrant"`
+	StartOrder string `json:"startOrder"`
+}
+
+type TurnPayload struct {
+	Turn      string               `json:"turn"`
+	EventName string               `json:"eventName"`
+	Version   int                  `json:"version"`
+	Checksum  string               `json:"checksum"`
+	Changes   []pawn.PawnPositions `json:"changes"`
+}
+
+type DiceRollPayload struct {
+	EventName string `json:"eventName"`
+	RequestId string `json:"requestId,omitempty"`
+}
+
+type DiceRollingPayload struct {
+	EventName string `json:"eventName"`
+}
+
+type DiceRolledPayload struct {
+	Number            int        `json:"number"`
+	EventName         string     `json:"eventName"`
+	MovablePawns      []string   `json:"movablePawns"`
+	Quadrant          string     `json:"quadrant"`
+	Forfeited         bool       `json:"forfeited"`
+	Values            []int      `json:"values,omitempty"`
+	MovablePawnsByDie [][]string `json:"movablePawnsByDie,omitempty"`
+}
+
+type BoardHintPayload struct {
+	EventName     string           `json:"eventName"`
+	Quadrant      string           `json:"quadrant"`
+	Steps         int              `json:"steps"`
+	SuggestedPawn string           `json:"suggestedPawn"`
+	Moves         []board.HintMove `json:"moves"`
+}
+
+type PawnMovePayload struct {
+	EventName string `json:"eventName"`
+	Quadrant  string `json:"quadrant"`
+	Pawn      string `json:"pawn"`
+	Steps     int    `json:"steps"`
+	Die       int    `json:"die"`
+	RequestId string `json:"requestId,omitempty"`
+}
+
+type PawnMoveRejectedPayload struct {
+	EventName    string   `json:"eventName"`
+	Quadrant     string   `json:"quadrant"`
+	Pawn         string   `json:"pawn"`
+	Steps        int      `json:"steps"`
+	Code         string   `json:"code"`
+	Reason       string   `json:"reason"`
+	MovablePawns []string `json:"movablePawns"`
+	Violations   int      `json:"violations"`
+}
+
+type PawnMovedPayload struct {
+	EventName        string                 `json:"eventName"`
+	Pawn             string                 `json:"pawn"`
+	Steps            int                    `json:"steps"`
+	Quadrant         string                 `json:"quadrant"`
+	RolledBy         string                 `json:"rolledBy"`
+	ResponseCode     int                    `json:"responseCode"`
+	InitialPosition  int                    `json:"initialPosition"`
+	FinalPosition    int                    `json:"finalPosition"`
+	InitialIndex     int                    `json:"initialIndex"`
+	FinalIndex       int                    `json:"finalIndex"`
+	IsAtHome         bool                   `json:"isAtHome"`
+	CapturedPawns    []string               `json:"capturedPawns"`
+	ValidationErrors []pawn.ValidationError `json:"validationErrors"`
+	Version          int                    `json:"version"`
+	Checksum         string                 `json:"checksum"`
+	Changes          []pawn.PawnPositions   `json:"changes"`
+	Path             []int                  `json:"path"`
+	Die              int                    `json:"die"`
+	RemainingDice    []int                  `json:"remainingDice,omitempty"`
+}
+
+type SpectatorWinProbabilityPayload struct {
+	EventName     string                         `json:"eventName"`
+	MoveNumber    int                            `json:"moveNumber"`
+	Probabilities []board.QuadrantWinProbability `json:"probabilities"`
+}
+
+type DisconnectionPayload struct {
+	EventName string `json:"eventName"`
+	Player    string `json:"player"`
+}
+
+type BoardReconnectionPayload struct {
+	EventName    string                  `json:"eventName"`
+	Participants []board.ParticipantInfo `json:"participants"`
+	Positions    []pawn.PawnPositions    `json:"positions"`
+	Turn         string                  `json:"turn"`
+	Version      int                     `json:"version"`
+	Checksum     string                  `json:"checksum"`
+}
+
+type SnapshotPayload struct {
+	EventName string `json:"eventName"`
+	RequestId string `json:"requestId,omitempty"`
+}
+
+type StateSnapshotPayload struct {
+	EventName string               `json:"eventName"`
+	Version   int                  `json:"version"`
+	Checksum  string               `json:"checksum"`
+	Turn      string               `json:"turn"`
+	Positions []pawn.PawnPositions `json:"positions"`
+	RequestId string               `json:"requestId,omitempty"`
+}
+
+type GameWinnerPayload struct {
+	EventName     string `json:"eventName"`
+	Winner        string `json:"winner"`
+	WinningAmount int    `json:"winningAmount"`
+	ResponseCode  int    `json:"responseCode"`
+}
+
+type ErrorPayload struct {
+	EventName    string `json:"eventName"`
+	ErrorCode    int    `json:"errorCode"`
+	ErrorMessage string `json:"errorMessage"`
+	Code         string `json:"code"`
+	RequestId    string `json:"requestId,omitempty"`
+}
+
+type ServerDrainingPayload struct {
+	EventName string    `json:"eventName"`
+	Message   string    `json:"message"`
+	ClosesAt  time.Time `json:"closesAt"`
+}
+
+type GameEndPayload struct {
+	EventName     string                `json:"eventName"`
+	Winner        string                `json:"winner"`
+	Winners       []string              `json:"winners"`
+	WinningAmount int                   `json:"winningAmount"`
+	ResponseCode  int                   `json:"responseCode"`
+	TimeUp        bool                  `json:"timeUp,omitempty"`
+	Scoreboard    []board.QuadrantScore `json:"scoreboard,omitempty"`
+}
@@ -0,0 +1,67 @@
+package protocol
+
+import (
+	"ludo/ludo_board_constants"
+	"messaging/common"
+)
+
+//go:generate go run protocol_generate.go
+
+// Direction tells who sends a message over the socket
+type Direction string
+
+const (
+	SERVER_TO_CLIENT Direction = "SERVER_TO_CLIENT"
+	CLIENT_TO_SERVER Direction = "CLIENT_TO_SERVER"
+)
+
+// Event is a socket message: its event name, who sends it and the struct of its payload
+type Event struct {
+	Name      string
+	Direction Direction
+	Summary   string
+	Payload   interface{} // Zero value of the payload struct, the schema of the message is generated from it
+}
+
+// Events is the registry of the socket messages of Ludo. The JSON Schema and the AsyncAPI document are
+// generated from it with go generate, and the tests check every message against it.
+var Events = []Event{
+	{ludo_board_constants.GAME_INITIALIZE, SERVER_TO_CLIENT, "Board settings and geometry, sent to a player joining the board", GameInitializePayload{}},
+	{ludo_board_constants.BOARD_WAITING_PLAYERS, SERVER_TO_CLIENT, "Players seated on the board while it waits for more", BoardWaitingPlayersPayload{}},
+	{ludo_board_constants.BOARD_BET_FAILED, SERVER_TO_CLIENT, "The ticket of the player could not be paid", BoardBetFailedPayload{}},
+	{ludo_board_constants.SELECT_QUADRANT, SERVER_TO_CLIENT, "Quadrants the player can pick from", SelectQuadrantPayload{}},
+	{ludo_board_constants.BOARD_SELECTING_QUADRANT, SERVER_TO_CLIENT, "Player picking a quadrant", BoardSelectingQuadrantPayload{}},
+	{ludo_board_constants.QUADRANT_SELECT, CLIENT_TO_SERVER, "Quadrant picked by the player", QuadrantSelectPayload{}},
+	{ludo_board_constants.BOARD_JOINED, SERVER_TO_CLIENT, "Players and their quadrants once the seating is done", BoardJoinedPayload{}},
+	{ludo_board_constants.GAME_START, SERVER_TO_CLIENT, "The game starts", GameStartPayload{}},
+	{ludo_board_constants.GAME_DICE_OFF, SERVER_TO_CLIENT, "A round of the dice-off picking the first turn", GameDiceOffPayload{}},
+	{ludo_board_constants.GAME_FIRST_TURN, SERVER_TO_CLIENT, "Quadrant playing first", GameFirstTurnPayload{}},
+	{ludo_board_constants.GAME_COUNTDOWN, SERVER_TO_CLIENT, "Time left on a timed board", GameCountdownPayload{}},
+	{ludo_board_constants.TURN, SERVER_TO_CLIENT, "Quadrant to roll, with the version of the board and the pawns moved since the last message", TurnPayload{}},
+	{ludo_board_constants.BOARD_DICEROLL, CLIENT_TO_SERVER, "The player rolls the dice", DiceRollPayload{}},
+	{ludo_board_constants.BOARD_DICEROLLING, SERVER_TO_CLIENT, "The dice are rolling", DiceRollingPayload{}},
+	{ludo_board_constants.BOARD_DICEROLLED, SERVER_TO_CLIENT, "Roll of the quadrant and the pawns it can move", DiceRolledPayload{}},
+	{ludo_board_constants.BOARD_HINT, SERVER_TO_CLIENT, "Suggested move on practice tables", BoardHintPayload{}},
+	{ludo_board_constants.BOARD_MOVEPAWN, CLIENT_TO_SERVER, "Pawn the player moves with a die", PawnMovePayload{}},
+	{ludo_board_constants.BOARD_MOVE_REJECTED, SERVER_TO_CLIENT, "A pawn move the server refused, sent to its sender only", PawnMoveRejectedPayload{}},
+	{ludo_board_constants.BOARD_PAWNMOVED, SERVER_TO_CLIENT, "A pawn moved, with the version of the board and the pawns it moved", PawnMovedPayload{}},
+	{ludo_board_constants.SPECTATOR_WIN_PROBABILITY, SERVER_TO_CLIENT, "Win probability of every quadrant, sent to the spectators", SpectatorWinProbabilityPayload{}},
+	{ludo_board_constants.PLAYER_DISCONNECTED, SERVER_TO_CLIENT, "A player left the board", DisconnectionPayload{}},
+	{ludo_board_constants.BOARD_RECONNECTION, SERVER_TO_CLIENT, "The board as it is, sent to a player reconnecting", BoardReconnectionPayload{}},
+	{ludo_board_constants.BOARD_SNAPSHOT, CLIENT_TO_SERVER, "The checksum of the client does not match, it asks for the full state", SnapshotPayload{}},
+	{ludo_board_constants.BOARD_STATE_SNAPSHOT, SERVER_TO_CLIENT, "Full state of the board, sent to the player asking for it", StateSnapshotPayload{}},
+	{ludo_board_constants.GAME_WINNER, SERVER_TO_CLIENT, "A player won", GameWinnerPayload{}},
+	{ludo_board_constants.GAME_END, SERVER_TO_CLIENT, "The game is over, with the winners and the scoreboard of timed boards", GameEndPayload{}},
+	{common.ERROR_EVENT, SERVER_TO_CLIENT, "A message failed, with the code of the error and the request id of the message", ErrorPayload{}},
+	{common.SERVER_DRAINING_EVENT, SERVER_TO_CLIENT, "The server is shutting down, the connection is closed by closesAt", ServerDrainingPayload{}},
+}
+
+// Lookup returns the registered event with the given name
+func Lookup(name string) (Event, bool) {
+	for _, event := range Events {
+		if event.Name == name {
+			return event, true
+		}
+	}
+	return Event{}, false
+}
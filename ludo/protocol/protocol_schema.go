@@ -0,0 +1,162 @@
+package protocol
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	JSON_SCHEMA_DRAFT = "https://json-schema.org/draft/2020-12/schema"
+	ASYNCAPI_VERSION  = "2.6.0"
+	SCHEMA_ID         = "https://ludo-server/protocol/protocol_schema.json"
+	SCHEMA_FILE       = "protocol_schema.json"
+	ASYNCAPI_FILE     = "protocol_asyncapi.json"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Schema returns the JSON Schema of the payload of an event, the eventName property is pinned to the name of the event
+func (event Event) Schema() map[string]interface{} {
+	schema := schemaOf(reflect.TypeOf(event.Payload))
+	schema["title"] = event.Name
+	schema["description"] = event.Summary
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		if _, ok := properties["eventName"]; ok {
+			properties["eventName"] = map[string]interface{}{"const": event.Name}
+		}
+	}
+	return schema
+}
+
+// schemaOf builds the schema of a Go type the way encoding/json writes it
+func schemaOf(t reflect.Type) map[string]interface{} {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		// A nil slice is written as null
+		return map[string]interface{}{"type": []string{"array", "null"}, "items": schemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaOf(t.Elem())}
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, omitEmpty, ok := jsonField(field)
+			if !ok {
+				continue
+			}
+			properties[name] = schemaOf(field.Type)
+			if !omitEmpty {
+				required = append(required, name)
+			}
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	}
+	return map[string]interface{}{}
+}
+
+// jsonField returns the name encoding/json gives to a struct field and whether it is left out when empty
+func jsonField(field reflect.StructField) (string, bool, bool) {
+	if field.PkgPath != "" {
+		return "", false, false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	omitEmpty := false
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, true
+}
+
+// GenerateJSONSchema returns the JSON Schema of every registered event, one definition per event
+func GenerateJSONSchema() ([]byte, error) {
+	definitions := map[string]interface{}{}
+	oneOf := []interface{}{}
+	for _, event := range Events {
+		definitions[event.Name] = event.Schema()
+		oneOf = append(oneOf, map[string]interface{}{"$ref": "#/$defs/" + event.Name})
+	}
+	document := map[string]interface{}{
+		"$schema": JSON_SCHEMA_DRAFT,
+		"$id":     SCHEMA_ID,
+		"title":   "Ludo socket messages",
+		"oneOf":   oneOf,
+		"$defs":   definitions,
+	}
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// GenerateAsyncAPI returns the AsyncAPI document of the socket. Following AsyncAPI 2, publish holds the messages the
+// clients send and subscribe the messages the server sends.
+func GenerateAsyncAPI() ([]byte, error) {
+	messages := map[string]interface{}{}
+	publish := []interface{}{}
+	subscribe := []interface{}{}
+	for _, event := range Events {
+		messages[event.Name] = map[string]interface{}{
+			"name":    event.Name,
+			"summary": event.Summary,
+			"payload": event.Schema(),
+		}
+		ref := map[string]interface{}{"$ref": "#/components/messages/" + event.Name}
+		if event.Direction == CLIENT_TO_SERVER {
+			publish = append(publish, ref)
+		} else {
+			subscribe = append(subscribe, ref)
+		}
+	}
+	document := map[string]interface{}{
+		"asyncapi": ASYNCAPI_VERSION,
+		"info": map[string]interface{}{
+			"title":   "Ludo socket",
+			"version": "1.0.0",
+		},
+		"defaultContentType": "application/json",
+		"channels": map[string]interface{}{
+			"/ws": map[string]interface{}{
+				"publish": map[string]interface{}{
+					"operationId": "sendMessage",
+					"message":     map[string]interface{}{"oneOf": publish},
+				},
+				"subscribe": map[string]interface{}{
+					"operationId": "receiveMessage",
+					"message":     map[string]interface{}{"oneOf": subscribe},
+				},
+			},
+		},
+		"components": map[string]interface{}{"messages": messages},
+	}
+	return json.MarshalIndent(document, "", "  ")
+}
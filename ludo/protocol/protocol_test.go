@@ -0,0 +1,137 @@
+package protocol
+
+import (
+	"ludo/board"
+	"ludo/dice"
+	c "ludo/ludo_board_constants"
+	"ludo/pawn"
+	"ludo/quadrant"
+	"ludo/rules"
+	"messaging/common"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// wireMessage is what the samples need of a message, DiceRollMessage is read only and is no common.Message
+type wireMessage interface {
+	ToJSON() (string, error)
+}
+
+// rawMessage is a message clients send that the server has no type for
+type rawMessage string
+
+func (m rawMessage) ToJSON() (string, error) {
+	return string(m), nil
+}
+
+// samples builds every registered message with all its fields set, so a field added to a ToJSON and not to the
+// payload of the registry, or the other way round, fails the tests
+func samples() map[string]wireMessage {
+	player := board.Player{Id: "player-1", Name: "Player 1"}
+	participants := []board.ParticipantInfo{{Player: player, Quadrant: "QUADRANT_1", IsBot: false, Team: "TEAM_1"}}
+	positions := []pawn.PawnPositions{{
+		Quadrant:      "QUADRANT_1",
+		PawnPositions: []pawn.PawnPosition{{Name: "Q1_P1", CurrentPosition: 3}},
+		Blockades:     []int{3},
+	}}
+	sync := pawn.StateSync{Version: 4, Checksum: pawn.StateChecksum(positions), Changes: positions}
+	snapshot := board.StateSnapshot{Version: 4, Checksum: pawn.StateChecksum(positions), Turn: "QUADRANT_1", Positions: positions}
+	grid := board.Grid{
+		Layout: "CLASSIC",
+		Rows:   15,
+		Cols:   15,
+		Cells:  []quadrant.Cell{{Position: 1, Row: 0, Col: 1, Type: "START", Quadrant: "QUADRANT_1"}},
+	}
+	quadrants := []board.Quadrant{{Name: "QUADRANT_1", Color: "RED", Pawns: []string{"Q1_P1"}, Path: []int{1, 2}, Team: "TEAM_1"}}
+
+	return map[string]wireMessage{
+		c.GAME_INITIALIZE:            board.NewGameInitializeMessage(c.GAME_INITIALIZE, []int{1}, quadrants, true, 2, 10, player, 15, rules.CLASSIC_RULES, grid),
+		c.BOARD_WAITING_PLAYERS:      board.NewBoardWaitingPlayersMessage(c.BOARD_WAITING_PLAYERS, []board.Player{player}, player, player),
+		c.BOARD_BET_FAILED:           board.NewBoardBetFailedMessage(c.BOARD_BET_FAILED, "Insufficient balance"),
+		c.SELECT_QUADRANT:            quadrant.NewSelectQuadrantMessage(c.SELECT_QUADRANT, []string{"QUADRANT_1"}, 200),
+		c.BOARD_SELECTING_QUADRANT:   board.NewBoardSelectingQuadrantMessage(c.BOARD_SELECTING_QUADRANT, player),
+		c.QUADRANT_SELECT:            quadrant.NewQuadrantSelectMessage(c.QUADRANT_SELECT, "QUADRANT_1"),
+		c.BOARD_JOINED:               board.NewBoardJoinedMessage(c.BOARD_JOINED, participants, player),
+		c.GAME_START:                 board.NewGameStartMessage(c.GAME_START),
+		c.GAME_DICE_OFF:              board.NewGameDiceOffMessage(c.GAME_DICE_OFF, 1, []board.DiceOffRoll{{Quadrant: "QUADRANT_1", PlayerId: "player-1", Value: 6}}, []string{"QUADRANT_1"}),
+		c.GAME_FIRST_TURN:            board.NewGameFirstTurnMessage(c.GAME_FIRST_TURN, "QUADRANT_1", "CLOCKWISE"),
+		c.GAME_COUNTDOWN:             board.NewGameCountdownMessage(c.GAME_COUNTDOWN, 60, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)),
+		c.TURN:                       board.NewTurnMessage(c.TURN, "QUADRANT_1", sync),
+		c.BOARD_DICEROLL:             dice.NewDiceRollMessage(c.BOARD_DICEROLL),
+		c.BOARD_DICEROLLING:          dice.NewDiceRollingMessage(c.BOARD_DICEROLLING),
+		c.BOARD_DICEROLLED:           dice.NewDiceRolledMessage(c.BOARD_DICEROLLED, 6, "QUADRANT_1", []string{"Q1_P1"}, false, []int{6, 2}, [][]string{{"Q1_P1"}, {}}),
+		c.BOARD_HINT:                 board.NewBoardHintMessage(c.BOARD_HINT, "QUADRANT_1", 6, "Q1_P1", []board.HintMove{{Pawn: "Q1_P1", Score: 1.5}}),
+		c.BOARD_MOVEPAWN:             pawn.NewPawnMoveMessage(c.BOARD_MOVEPAWN, "QUADRANT_1", "Q1_P1", 6, 0),
+		c.BOARD_MOVE_REJECTED:        pawn.NewPawnMoveRejectedMessage(c.BOARD_MOVE_REJECTED, "QUADRANT_1", "Q1_P1", 6, "NOT_MOVABLE", "The pawn cannot move", []string{"Q1_P2"}, 1),
+		c.SPECTATOR_WIN_PROBABILITY:  board.NewSpectatorWinProbabilityMessage(c.SPECTATOR_WIN_PROBABILITY, board.WinProbabilities{MoveNumber: 3, Probabilities: []board.QuadrantWinProbability{{Quadrant: "QUADRANT_1", PlayerId: "player-1", Probability: 0.5}}}),
+		c.PLAYER_DISCONNECTED:        board.NewDisconnectionMessage(c.PLAYER_DISCONNECTED, "player-1"),
+		c.BOARD_RECONNECTION:         board.NewBoardReconnectionMessage(participants, snapshot),
+		c.BOARD_SNAPSHOT:             rawMessage(`{"eventName":"` + c.BOARD_SNAPSHOT + `","requestId":"request-1"}`),
+		c.BOARD_STATE_SNAPSHOT:       board.NewBoardStateSnapshotMessage(c.BOARD_STATE_SNAPSHOT, snapshot, "request-1"),
+		c.GAME_WINNER:                board.NewGameWinnerMessage(c.GAME_WINNER, "player-1", 18, 200),
+		c.GAME_END:                   board.NewGameEndMessage(c.GAME_END, []string{"player-1"}, 18, 200).SetScoreboard(true, []board.QuadrantScore{{Rank: 1, Quadrant: "QUADRANT_1", PlayerId: "player-1", Score: 10}}),
+		common.ERROR_EVENT:           common.NewErrorMessage(common.NewSocketError(common.INVALID_TURN, "Invalid player"), "request-1"),
+		common.SERVER_DRAINING_EVENT: common.NewServerDrainingMessage("Server is shutting down", time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC)),
+		c.BOARD_PAWNMOVED: pawn.NewPawnMovedMessage(map[string]interface{}{
+			"eventName":        c.BOARD_PAWNMOVED,
+			"pawn":             "Q1_P1",
+			"steps":            6,
+			"initialPosition":  1,
+			"finalPosition":    7,
+			"initialIndex":     0,
+			"finalIndex":       6,
+			"isAtHome":         false,
+			"quadrant":         "QUADRANT_1",
+			"rolledBy":         "QUADRANT_1",
+			"capturedPawns":    []string{"Q2_P1"},
+			"responseCode":     200,
+			"validationErrors": []pawn.ValidationError{{Message: "Blocked", CurrentLocation: "1"}},
+			"sync":             sync,
+			"path":             []int{2, 3, 4, 5, 6, 7},
+			"die":              0,
+			"remainingDice":    []int{1},
+		}),
+	}
+}
+
+func TestMessagesMatchTheSchema(t *testing.T) {
+	messages := samples()
+	assert.Len(t, messages, len(Events), "Every registered event should have a sample message")
+
+	for _, event := range Events {
+		message, ok := messages[event.Name]
+		if !assert.True(t, ok, "Missing sample message for %s", event.Name) {
+			continue
+		}
+		data, err := message.ToJSON()
+		assert.NoError(t, err)
+		assert.NoError(t, Validate(event.Name, data), "%s drifted from its schema: %s", event.Name, data)
+	}
+}
+
+func TestValidateRejectsDrift(t *testing.T) {
+	diceRoll := `{"eventName":"` + c.BOARD_DICEROLL + `"}`
+	assert.NoError(t, Validate(c.BOARD_DICEROLL, diceRoll))
+	assert.NoError(t, Validate(c.BOARD_DICEROLL, `{"eventName":"`+c.BOARD_DICEROLL+`","requestId":"request-1"}`), "Clients can set a request id on their messages")
+	assert.Error(t, Validate(c.BOARD_DICEROLL, `{"eventName":"`+c.BOARD_DICEROLL+`","extra":1}`), "Unknown properties should be rejected")
+	assert.Error(t, Validate(c.BOARD_DICEROLL, `{}`), "Missing properties should be rejected")
+	assert.Error(t, Validate(c.BOARD_DICEROLL, `{"eventName":"`+c.TURN+`"}`), "The event name should match the event")
+	assert.Error(t, Validate(c.GAME_WINNER, `{"eventName":"`+c.GAME_WINNER+`","winner":"p","winningAmount":"18","responseCode":200}`), "Wrong types should be rejected")
+	assert.Error(t, Validate("Board.Unknown", diceRoll), "Unregistered events should be rejected")
+}
+
+func TestGeneratedFilesAreUpToDate(t *testing.T) {
+	schema, err := GenerateJSONSchema()
+	assert.NoError(t, err)
+	asyncAPI, err := GenerateAsyncAPI()
+	assert.NoError(t, err)
+
+	for file, generated := range map[string][]byte{SCHEMA_FILE: schema, ASYNCAPI_FILE: asyncAPI} {
+		written, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		assert.Equal(t, string(generated)+"\n", string(written), "%s is stale, run go generate ./protocol", file)
+	}
+}
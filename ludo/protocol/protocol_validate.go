@@ -0,0 +1,125 @@
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Validate checks a JSON message against the schema of its event
+func Validate(eventName string, data string) error {
+	event, ok := Lookup(eventName)
+	if !ok {
+		return errors.New("unknown event " + eventName)
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(data), &value); err != nil {
+		return err
+	}
+	return validate(event.Schema(), value, "$")
+}
+
+func validate(schema map[string]interface{}, value interface{}, path string) error {
+	if constant, ok := schema["const"]; ok {
+		if value != constant {
+			return fmt.Errorf("%s: expected %v, got %v", path, constant, value)
+		}
+		return nil
+	}
+	types := []string{}
+	switch t := schema["type"].(type) {
+	case string:
+		types = append(types, t)
+	case []string:
+		types = append(types, t...)
+	}
+	if len(types) == 0 {
+		return nil
+	}
+	kind := kindOf(value)
+	if !typeAllowed(types, kind) {
+		return fmt.Errorf("%s: expected %v, got %s", path, types, kind)
+	}
+	switch kind {
+	case "string":
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, value.(string)); err != nil {
+				return fmt.Errorf("%s: %v", path, err)
+			}
+		}
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		for i, item := range value.([]interface{}) {
+			if err := validate(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "object":
+		return validateObject(schema, value.(map[string]interface{}), path)
+	}
+	return nil
+}
+
+func validateObject(schema map[string]interface{}, object map[string]interface{}, path string) error {
+	if required, ok := schema["required"].([]string); ok {
+		for _, name := range required {
+			if _, ok := object[name]; !ok {
+				return fmt.Errorf("%s: missing property %s", path, name)
+			}
+		}
+	}
+	properties, _ := schema["properties"].(map[string]interface{})
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if properties != nil {
+			property, ok := properties[name]
+			if !ok {
+				return fmt.Errorf("%s: unexpected property %s", path, name)
+			}
+			if err := validate(property.(map[string]interface{}), object[name], path+"."+name); err != nil {
+				return err
+			}
+		} else if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+			if err := validate(additional, object[name], path+"."+name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func kindOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "unknown"
+}
+
+func typeAllowed(types []string, kind string) bool {
+	for _, t := range types {
+		if t == kind || (t == "number" && kind == "integer") {
+			return true
+		}
+	}
+	return false
+}
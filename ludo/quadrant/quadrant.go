@@ -3,7 +3,6 @@ package quadrant
 import (
 	"errors"
 	"fmt"
-	"log"
 
 	"ludo/pawn"
 	"ludo/player"
@@ -21,18 +20,18 @@ type Quadrant struct {
 }
 
 // NewQuadrant creates a new quadrant with the specified color and initializes its pawns
-func NewQuadrant(color string, player *player.Player, quadrantName string, path []int) *Quadrant {
+func NewQuadrant(color string, player *player.Player, quadrantName string, path []int, pawnsPerQuadrant int) *Quadrant {
 	q := &Quadrant{
 		name:       quadrantName,
 		color:      color,
-		pawns:      make([]*pawn.Pawn, 0, 4),
+		pawns:      make([]*pawn.Pawn, 0, pawnsPerQuadrant),
 		path:       path,
 		player:     player,
 		isOccupied: false,
 	}
 
-	// Initialize 4 pawns for this quadrant
-	for i := 1; i <= 4; i++ {
+	// Initialize the pawns of this quadrant
+	for i := 1; i <= pawnsPerQuadrant; i++ {
 		pawnName := fmt.Sprintf("%s_%s_%d", quadrantName, "PAWN", i)
 		pawn := pawn.NewPawn(color, pawnName, nil, path)
 		q.pawns = append(q.pawns, pawn)
@@ -148,46 +147,44 @@ func (q *Quadrant) CountFinishedPawns() int {
 // HasWon checks if all pawns from this quadrant have finished
 // Returns true if all pawns are FINISHED
 func (q *Quadrant) HasWon() bool {
-	return q.CountFinishedPawns() == 4
+	return q.CountFinishedPawns() == len(q.pawns)
 }
 
-// GetQuadrantConfig retrieves the quadrant configuration from the database
-func GetQuadrantConfig() (string, error) {
+// GetQuadrantConfig retrieves the geometry of the layout from the database
+func GetQuadrantConfig(layout string) (QuadrantConfigSchema, error) {
 	quadrantDao := NewQuadrantDao()
 
-	quadrantConfig, err := quadrantDao.FetchAndProcessQuadrantConfig()
+	quadrantConfig, err := quadrantDao.GetQuadrantConfig(layout)
 
 	if err != nil {
-		return "", err
+		return QuadrantConfigSchema{}, err
 	}
 
 	return quadrantConfig, nil
 }
 
-func InitializeQuadrantConfigIfNotExists(quadrantNames map[int]string,
-	quadrantPaths map[string][]int,
-	quadrantColors map[string]string,
-	safePositions []int) (string, error) {
-
+// InitializeQuadrantConfigIfNotExists returns the geometry of the layout stored in the database, after storing
+// the given default when there is none yet. The geometry is validated before it is returned.
+func InitializeQuadrantConfigIfNotExists(defaultConfig QuadrantConfigSchema) (QuadrantConfigSchema, error) {
 	quadrantDao := NewQuadrantDao()
 
-	newQuadrantConfig := NewQuadrantConfig(quadrantNames, quadrantPaths, quadrantColors, safePositions)
-
-	quadrantConfig, err := GetQuadrantConfig()
+	quadrantConfig, err := GetQuadrantConfig(defaultConfig.Layout)
 
 	if err == mongo.ErrNoDocuments {
-		quadrantConfigInsertError := quadrantDao.InsertQuadrantConfig(newQuadrantConfig)
+		quadrantConfigInsertError := quadrantDao.InsertQuadrantConfig(defaultConfig)
 		if quadrantConfigInsertError != nil {
-			log.Fatal(quadrantConfigInsertError)
-			return "", quadrantConfigInsertError
+			return QuadrantConfigSchema{}, quadrantConfigInsertError
 		}
 
-		quadrantConfig, err = GetQuadrantConfig()
+		quadrantConfig, err = GetQuadrantConfig(defaultConfig.Layout)
+	}
 
-		if err != nil {
-			log.Fatal(err)
-			return "", err
-		}
+	if err != nil {
+		return QuadrantConfigSchema{}, err
+	}
+
+	if err := quadrantConfig.Validate(); err != nil {
+		return QuadrantConfigSchema{}, err
 	}
 
 	return quadrantConfig, nil
@@ -1,117 +1,266 @@
 package quadrant
 
 import (
-	// "messaging/common/constants"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"fmt"
+	"math"
+	"sort"
 )
 
+// QuadrantSchema is the part of the board geometry that belongs to one quadrant
 type QuadrantSchema struct {
-	Name  string `bson:"name"`
-	Path  []int
-	Color string `bson:"color"`
+	Name          string `bson:"name" json:"name"`
+	Color         string `bson:"color" json:"color"`
+	Path          []int  `bson:"path" json:"path"`                   // Positions the pawns follow, from the start square to the finish
+	StartPosition int    `bson:"startPosition" json:"startPosition"` // Square an unlocked pawn enters the board on, the first of the path
+	HomeColumn    []int  `bson:"homeColumn" json:"homeColumn"`       // Last squares of the path only the pawns of the quadrant enter, the finish included
 }
 
+// QuadrantConfigSchema is the geometry of a board layout, as stored in the quadrantConfig collection.
+// Positions are indexes in a grid of Rows x Cols squares, read row by row.
 type QuadrantConfigSchema struct {
-	SafePositions []int          `json:"safePositions" bson:"safePositions"`
-	QUADRANT_1    QuadrantSchema `json:"QUADRANT_1" bson:""`
-	QUADRANT_2    QuadrantSchema `json:"QUADRANT_2" bson:""`
-	QUADRANT_3    QuadrantSchema `json:"QUADRANT_3" bson:""`
-	QUADRANT_4    QuadrantSchema `json:"QUADRANT_4," bson:""`
+	Layout        string           `bson:"layout" json:"layout"`
+	Rows          int              `bson:"rows" json:"rows"`
+	Cols          int              `bson:"cols" json:"cols"`
+	SafePositions []int            `bson:"safePositions" json:"safePositions"`
+	Quadrants     []QuadrantSchema `bson:"quadrants" json:"quadrants"` // In turn order
 }
 
-// NewQuadrantConfig creates a new QuadrantConfig instance.
-// Accept arguements for all the quadrants and safe positions.
+// NewQuadrantConfig creates the geometry of the classic board from the quadrant names, paths and colors.
 func NewQuadrantConfig(
+	layout string,
 	quadrantNames map[int]string,
 	quadrantPaths map[string][]int,
 	quadrantColors map[string]string,
 	safePositions []int,
+	homeColumnLength int,
+	gridSize int,
 ) QuadrantConfigSchema {
-	return QuadrantConfigSchema{
+	config := QuadrantConfigSchema{
+		Layout:        layout,
+		Rows:          gridSize,
+		Cols:          gridSize,
 		SafePositions: safePositions,
-		QUADRANT_1: QuadrantSchema{
-			Path:  quadrantPaths[quadrantNames[1]],
-			Name:  quadrantNames[1],
-			Color: quadrantColors[quadrantNames[1]],
-		},
-		QUADRANT_2: QuadrantSchema{
-			Path:  quadrantPaths[quadrantNames[2]],
-			Name:  quadrantNames[2],
-			Color: quadrantColors[quadrantNames[2]],
-		},
-		QUADRANT_3: QuadrantSchema{
-			Path:  quadrantPaths[quadrantNames[3]],
-			Name:  quadrantNames[3],
-			Color: quadrantColors[quadrantNames[3]],
-		},
-		QUADRANT_4: QuadrantSchema{
-			Path:  quadrantPaths[quadrantNames[4]],
-			Name:  quadrantNames[4],
-			Color: quadrantColors[quadrantNames[4]],
-		},
 	}
+
+	for i := 1; i <= len(quadrantNames); i++ {
+		name := quadrantNames[i]
+		path := quadrantPaths[name]
+
+		quadrantSchema := QuadrantSchema{
+			Name:  name,
+			Color: quadrantColors[name],
+			Path:  path,
+		}
+		if len(path) > 0 {
+			quadrantSchema.StartPosition = path[0]
+		}
+		if len(path) >= homeColumnLength {
+			quadrantSchema.HomeColumn = path[len(path)-homeColumnLength:]
+		}
+
+		config.Quadrants = append(config.Quadrants, quadrantSchema)
+	}
+
+	return config
+}
+
+// NewRingQuadrantConfig creates the geometry of a board where the quadrants share a ring shaped track.
+// Every quadrant owns armLength squares of the ring, starts on the first of them and leaves the ring
+// for a home column pointing to the center, right before getting back to its start square.
+func NewRingQuadrantConfig(layout string, quadrantNames []string, colors []string, armLength int, homeColumnLength int) QuadrantConfigSchema {
+	trackLength := len(quadrantNames) * armLength
+
+	// Squares are at least 1.6 apart, so no two of them round to the same grid square
+	radius := math.Ceil(1.6 * float64(trackLength) / (2 * math.Pi))
+	gridSize := 2*int(radius) + 1
+
+	position := func(r float64, angle float64) int {
+		row := int(math.Round(radius - r*math.Cos(angle)))
+		col := int(math.Round(radius + r*math.Sin(angle)))
+		return row*gridSize + col
+	}
+	angle := func(square int) float64 {
+		return 2 * math.Pi * float64(square%trackLength) / float64(trackLength)
+	}
+
+	config := QuadrantConfigSchema{
+		Layout: layout,
+		Rows:   gridSize,
+		Cols:   gridSize,
+	}
+
+	for i, name := range quadrantNames {
+		start := i * armLength
+
+		quadrantSchema := QuadrantSchema{
+			Name:          name,
+			Color:         colors[i],
+			StartPosition: position(radius, angle(start)),
+		}
+
+		for square := start; square < start+trackLength-1; square++ {
+			quadrantSchema.Path = append(quadrantSchema.Path, position(radius, angle(square)))
+		}
+
+		// The home column starts next to the square the pawn leaves the ring from
+		homeAngle := angle(start + trackLength - 1)
+		for k := 1; k <= homeColumnLength; k++ {
+			quadrantSchema.HomeColumn = append(quadrantSchema.HomeColumn, position(radius-1.5*float64(k), homeAngle))
+		}
+		quadrantSchema.Path = append(quadrantSchema.Path, quadrantSchema.HomeColumn...)
+
+		config.Quadrants = append(config.Quadrants, quadrantSchema)
+		config.SafePositions = append(config.SafePositions, position(radius, angle(start)), position(radius, angle(start+8)))
+	}
+
+	return config
+}
+
+// Validate checks that the geometry can be played: every position is on the grid, paths start on their start
+// square and end with their home column, home columns belong to a single quadrant and safe squares are on the track.
+func (c QuadrantConfigSchema) Validate() error {
+	if len(c.Quadrants) < 2 {
+		return fmt.Errorf("layout %s has %d quadrants, at least 2 are needed", c.Layout, len(c.Quadrants))
+	}
+	if c.Rows <= 0 || c.Cols <= 0 {
+		return fmt.Errorf("layout %s has an empty grid", c.Layout)
+	}
+
+	onGrid := func(position int) bool {
+		return position >= 0 && position < c.Rows*c.Cols
+	}
+
+	names := map[string]bool{}
+	owners := map[int]string{} // Home column squares by quadrant
+	track := map[int]bool{}
+
+	for _, q := range c.Quadrants {
+		if q.Name == "" || names[q.Name] {
+			return fmt.Errorf("layout %s has a missing or duplicate quadrant name %q", c.Layout, q.Name)
+		}
+		names[q.Name] = true
+
+		if q.Color == "" {
+			return fmt.Errorf("quadrant %s of layout %s has no color", q.Name, c.Layout)
+		}
+		if len(q.Path) == 0 || q.Path[0] != q.StartPosition {
+			return fmt.Errorf("path of quadrant %s of layout %s does not begin on its start square", q.Name, c.Layout)
+		}
+		if len(q.HomeColumn) == 0 || len(q.HomeColumn) >= len(q.Path) {
+			return fmt.Errorf("quadrant %s of layout %s has an invalid home column", q.Name, c.Layout)
+		}
+
+		visited := map[int]bool{}
+		for i, position := range q.Path {
+			if !onGrid(position) {
+				return fmt.Errorf("position %d of quadrant %s is outside the %dx%d grid of layout %s", position, q.Name, c.Rows, c.Cols, c.Layout)
+			}
+			if visited[position] {
+				return fmt.Errorf("path of quadrant %s of layout %s visits %d twice", q.Name, c.Layout, position)
+			}
+			visited[position] = true
+
+			homeIndex := i - (len(q.Path) - len(q.HomeColumn))
+			if homeIndex < 0 {
+				track[position] = true
+				continue
+			}
+			if q.HomeColumn[homeIndex] != position {
+				return fmt.Errorf("path of quadrant %s of layout %s does not end with its home column", q.Name, c.Layout)
+			}
+			owners[position] = q.Name
+		}
+	}
+
+	for position, owner := range owners {
+		if track[position] {
+			return fmt.Errorf("home column square %d of quadrant %s of layout %s is on the track", position, owner, c.Layout)
+		}
+	}
+	for _, q := range c.Quadrants {
+		for _, position := range q.Path {
+			if owner, ok := owners[position]; ok && owner != q.Name {
+				return fmt.Errorf("home column square %d of quadrant %s of layout %s is on the path of quadrant %s", position, owner, c.Layout, q.Name)
+			}
+		}
+	}
+
+	for _, position := range c.SafePositions {
+		if !track[position] {
+			return fmt.Errorf("safe square %d of layout %s is not on the track", position, c.Layout)
+		}
+	}
+
+	return nil
+}
+
+// CellType tells what a square of the board is used for
+type CellType string
+
+const (
+	CELL_TRACK       CellType = "TRACK"       // Shared by every quadrant
+	CELL_SAFE        CellType = "SAFE"        // On the track, pawns cannot be captured on it
+	CELL_START       CellType = "START"       // Where the pawns of a quadrant enter the board
+	CELL_HOME_COLUMN CellType = "HOME_COLUMN" // Only the pawns of one quadrant enter it
+	CELL_FINISH      CellType = "FINISH"      // Last square of a home column
+)
+
+// Cell is a square pawns can stand on, with its place on the grid
+type Cell struct {
+	Position int      `json:"position"`
+	Row      int      `json:"row"`
+	Col      int      `json:"col"`
+	Type     CellType `json:"type"`
+	Quadrant string   `json:"quadrant,omitempty"` // Owner of start, home column and finish squares
 }
 
-func ConvertToQuadrantMap(quadrantConfig primitive.M) (map[string]interface{}, error) {
-	// Convert _id to ObjectId() format
-	if id, ok := quadrantConfig["_id"].(primitive.ObjectID); ok {
-		quadrantConfig["_id"] = "ObjectId(" + id.Hex() + ")"
-	}
-
-	quadrantMap := map[string]interface{}{
-		"ID":            quadrantConfig["_id"],
-		"SafePositions": convertToIntSlice(quadrantConfig["safePositions"]),
-		"QUADRANT_1": map[string]interface{}{
-			"Path":  convertToIntSlice(quadrantConfig["quadrant_1"].(map[string]interface{})["path"]),
-			"Name":  quadrantConfig["quadrant_1"].(map[string]interface{})["name"].(string),
-			"Color": quadrantConfig["quadrant_1"].(map[string]interface{})["color"].(string),
-		},
-		"QUADRANT_2": map[string]interface{}{
-			"Path":  convertToIntSlice(quadrantConfig["quadrant_2"].(map[string]interface{})["path"]),
-			"Name":  quadrantConfig["quadrant_2"].(map[string]interface{})["name"].(string),
-			"Color": quadrantConfig["quadrant_2"].(map[string]interface{})["color"].(string),
-		},
-		"QUADRANT_3": map[string]interface{}{
-			"Path":  convertToIntSlice(quadrantConfig["quadrant_3"].(map[string]interface{})["path"]),
-			"Name":  quadrantConfig["quadrant_3"].(map[string]interface{})["name"].(string),
-			"Color": quadrantConfig["quadrant_3"].(map[string]interface{})["color"].(string),
-		},
-		"QUADRANT_4": map[string]interface{}{
-			"Path":  convertToIntSlice(quadrantConfig["quadrant_4"].(map[string]interface{})["path"]),
-			"Name":  quadrantConfig["quadrant_4"].(map[string]interface{})["name"].(string),
-			"Color": quadrantConfig["quadrant_4"].(map[string]interface{})["color"].(string),
-		},
-	}
-
-	return quadrantMap, nil
+// Coordinates returns the row and column of the position on the grid
+func (c QuadrantConfigSchema) Coordinates(position int) (int, int) {
+	return position / c.Cols, position % c.Cols
 }
 
-func convertToIntSlice(data interface{}) []int {
-	if data == nil {
-		return nil
+// Cells returns every square of the paths, ordered by position, so clients can draw the board from it
+func (c QuadrantConfigSchema) Cells() []Cell {
+	cells := map[int]*Cell{}
+
+	cell := func(position int) *Cell {
+		if _, ok := cells[position]; !ok {
+			row, col := c.Coordinates(position)
+			cells[position] = &Cell{Position: position, Row: row, Col: col, Type: CELL_TRACK}
+		}
+		return cells[position]
 	}
 
-	interfaceSlice, ok := data.([]interface{})
-	if !ok {
-		return nil
+	for _, q := range c.Quadrants {
+		for _, position := range q.Path {
+			cell(position)
+		}
+	}
+	for _, position := range c.SafePositions {
+		cell(position).Type = CELL_SAFE
 	}
+	for _, q := range c.Quadrants {
+		start := cell(q.StartPosition)
+		start.Type = CELL_START
+		start.Quadrant = q.Name
 
-	intSlice := make([]int, len(interfaceSlice))
-	for i, v := range interfaceSlice {
-		switch num := v.(type) {
-		case float64:
-			intSlice[i] = int(num)
-		case int:
-			intSlice[i] = num
-		case int32:
-			intSlice[i] = int(num)
-		case int64:
-			intSlice[i] = int(num)
-		default:
-			return nil
+		for i, position := range q.HomeColumn {
+			home := cell(position)
+			home.Type = CELL_HOME_COLUMN
+			if i == len(q.HomeColumn)-1 {
+				home.Type = CELL_FINISH
+			}
+			home.Quadrant = q.Name
 		}
 	}
 
-	return intSlice
+	result := make([]Cell, 0, len(cells))
+	for _, cell := range cells {
+		result = append(result, *cell)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Position < result[j].Position
+	})
+
+	return result
 }
@@ -0,0 +1,60 @@
+package quadrant
+
+import (
+	"ludo/ludo_board_constants"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltInLayoutsAreValid(t *testing.T) {
+	classic := NewQuadrantConfig(ludo_board_constants.CLASSIC_LAYOUT, ludo_board_constants.QuadrantsNames, ludo_board_constants.QuadrantsPaths, ludo_board_constants.QuadrantsColors, ludo_board_constants.SafePositions, ludo_board_constants.HOME_COLUMN_LENGTH, ludo_board_constants.GRID_SIZE)
+	assert.NoError(t, classic.Validate())
+	assert.Equal(t, []int{106, 107, 108, 109, 110, 111}, classic.Quadrants[0].HomeColumn)
+
+	cells := classic.Cells()
+	assert.Len(t, cells, 52+4*ludo_board_constants.HOME_COLUMN_LENGTH, "The track and the home columns should be drawn")
+	for _, cell := range cells {
+		switch cell.Position {
+		case 91:
+			assert.Equal(t, Cell{Position: 91, Row: 6, Col: 1, Type: CELL_START, Quadrant: "QUADRANT_1"}, cell)
+		case 36:
+			assert.Equal(t, CELL_SAFE, cell.Type)
+		case 111:
+			assert.Equal(t, Cell{Position: 111, Row: 7, Col: 6, Type: CELL_FINISH, Quadrant: "QUADRANT_1"}, cell)
+		}
+	}
+
+	for layout, quadrants := range ludo_board_constants.RING_LAYOUTS {
+		var names []string
+		for i := 0; i < quadrants; i++ {
+			names = append(names, ludo_board_constants.LayoutColors[i])
+		}
+
+		ring := NewRingQuadrantConfig(layout, names, ludo_board_constants.LayoutColors, ludo_board_constants.ARM_LENGTH, ludo_board_constants.HOME_COLUMN_LENGTH)
+
+		assert.NoError(t, ring.Validate(), layout)
+		assert.Len(t, ring.Quadrants, quadrants, layout)
+		assert.Len(t, ring.Quadrants[0].Path, quadrants*ludo_board_constants.ARM_LENGTH-1+ludo_board_constants.HOME_COLUMN_LENGTH, "Pawns should go round the whole track, as on the classic board")
+	}
+}
+
+func TestValidateRejectsSharedHomeColumns(t *testing.T) {
+	config := QuadrantConfigSchema{
+		Layout: "BROKEN",
+		Rows:   3,
+		Cols:   3,
+		Quadrants: []QuadrantSchema{
+			{Name: "QUADRANT_1", Color: "RED", Path: []int{0, 1, 4}, StartPosition: 0, HomeColumn: []int{4}},
+			{Name: "QUADRANT_2", Color: "GREEN", Path: []int{1, 4, 8}, StartPosition: 1, HomeColumn: []int{8}},
+		},
+	}
+
+	assert.Error(t, config.Validate(), "A home column square should not be on another path")
+
+	config.Quadrants[1].Path = []int{1, 0, 8}
+	assert.NoError(t, config.Validate())
+
+	config.Rows = 2
+	assert.Error(t, config.Validate(), "Positions should be on the grid")
+}
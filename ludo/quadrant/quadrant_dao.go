@@ -2,14 +2,10 @@ package quadrant
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"log"
 	"metagame/gameserver/config"
 	"metagame/gameserver/helpers"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -25,9 +21,10 @@ func NewQuadrantDao() *QuadrantDao {
 	}
 }
 
-func (dao *QuadrantDao) GetQuadrantConfig() (QuadrantConfigSchema, error) {
+// GetQuadrantConfig returns the geometry of the layout
+func (dao *QuadrantDao) GetQuadrantConfig(layout string) (QuadrantConfigSchema, error) {
 	var quadrantConfig QuadrantConfigSchema
-	err := dao.collection.FindOne(context.Background(), bson.M{}).Decode(&quadrantConfig)
+	err := dao.collection.FindOne(context.Background(), bson.M{"layout": layout}).Decode(&quadrantConfig)
 	if err != nil {
 		return QuadrantConfigSchema{}, err
 	}
@@ -42,32 +39,3 @@ func (dao *QuadrantDao) InsertQuadrantConfig(quadrantConfig QuadrantConfigSchema
 	}
 	return nil
 }
-
-func (dao *QuadrantDao) FetchAndProcessQuadrantConfig() (string, error) {
-	if dao.collection == nil {
-		return "", fmt.Errorf("QuadrantConfigCollection is not initialized")
-	}
-
-	var quadrantConfig primitive.M
-
-	err := dao.collection.FindOne(context.Background(), bson.D{{}}).Decode(&quadrantConfig)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return "", err
-		}
-		log.Printf("Error fetching QuadrantConfig: %v", err)
-		return "", err
-	}
-
-	// Convert _id to ObjectId() format
-	if id, ok := quadrantConfig["_id"].(primitive.ObjectID); ok {
-		quadrantConfig["_id"] = "ObjectId(" + id.Hex() + ")"
-	}
-
-	jsonData, err := json.Marshal(quadrantConfig)
-	if err != nil {
-		return "", err
-	}
-
-	return string(jsonData), nil
-}
@@ -0,0 +1,472 @@
+package rules
+
+import (
+	"ludo/pawn"
+	"slices"
+)
+
+// QuadrantState is one playing quadrant of the game
+type QuadrantState struct {
+	Name      string
+	Path      []int
+	PawnNames []string
+	Pawns     []int  // Index of every pawn in Path, -1 while the pawn is idle
+	Team      string // Quadrants of the same team never capture each other, empty outside team games
+	Captures  int    // Opponent pawns the quadrant sent back, scored in timed games
+}
+
+// State is the whole game as the rules see it. It holds no connection, player or database detail,
+// so it can be copied freely by the engine while simulating future turns.
+type State struct {
+	Quadrants     []QuadrantState // Playing quadrants in turn order
+	SafePositions []int
+	Turn          int    // Index in Quadrants of the quadrant that rolls next
+	Phase         Phase  // What the quadrant having the turn has to do next
+	Dice          int    // Value of the last roll, of its first die with two dice
+	Rolled        []int  // Dice of the last roll, one or two
+	Used          []bool // Dice of the last roll already moved with
+	Bonus         bool   // The last roll already earned another one
+	Mover         int    // Index in Quadrants of the quadrant that moved last, it completes the turn
+	Sixes         int    // Sixes rolled in a row by the quadrant having the turn
+	Rules         RuleSet
+}
+
+// Move moves one pawn of the quadrant having the turn
+type Move struct {
+	Pawn  int // Index of the pawn in its quadrant
+	Steps int
+}
+
+// Outcome describes what a move did to the board
+type Outcome struct {
+	CapturedPawns []string // Names of the opponent pawns sent back
+	Finished      bool     // The pawn reached the end of its path
+	ExtraTurn     bool     // The quadrant rolls again
+}
+
+// FromPositions builds a state from the pawn positions sent to the clients.
+// Parameters:
+//   - positions: Positions of all the quadrants, -1 for idle pawns
+//   - paths: Path of every quadrant
+//   - safePositions: Positions where pawns cannot be captured
+//   - playing: Names of the quadrants with a player, in turn order
+//   - turn: Name of the quadrant that rolls next
+//   - ruleSet: Rules the board is played with
+func FromPositions(positions []pawn.PawnPositions, paths map[string][]int, safePositions []int, playing []string, turn string, ruleSet RuleSet) State {
+	state := State{
+		SafePositions: safePositions,
+		Rules:         ruleSet,
+	}
+
+	for _, name := range playing {
+		quadrantState := QuadrantState{
+			Name: name,
+			Path: paths[name],
+		}
+
+		for _, quadrantPositions := range positions {
+			if quadrantPositions.Quadrant != name {
+				continue
+			}
+			for _, p := range quadrantPositions.PawnPositions {
+				quadrantState.PawnNames = append(quadrantState.PawnNames, p.Name)
+				quadrantState.Pawns = append(quadrantState.Pawns, IndexInPath(quadrantState.Path, p.CurrentPosition))
+			}
+		}
+
+		if name == turn {
+			state.Turn = len(state.Quadrants)
+		}
+		state.Quadrants = append(state.Quadrants, quadrantState)
+	}
+
+	return state
+}
+
+// NewState returns the state of a game about to start, with the first roll given to the quadrant firstTurn
+// Parameters:
+//   - quadrants: Playing quadrants in turn order, with all their pawns idle
+//   - safePositions: Positions where pawns cannot be captured
+//   - firstTurn: Name of the quadrant that rolls first
+//   - ruleSet: Rules the board is played with
+func NewState(quadrants []QuadrantState, safePositions []int, firstTurn string, ruleSet RuleSet) State {
+	state := State{
+		Quadrants:     quadrants,
+		SafePositions: safePositions,
+		Phase:         PHASE_ROLL,
+		Rules:         ruleSet,
+	}
+
+	if turn := state.QuadrantIndex(firstTurn); turn != -1 {
+		state.Turn = turn
+	}
+
+	return state
+}
+
+// Clone returns a deep copy of the state
+func (s State) Clone() State {
+	clone := State{
+		Quadrants:     make([]QuadrantState, len(s.Quadrants)),
+		SafePositions: s.SafePositions,
+		Turn:          s.Turn,
+		Phase:         s.Phase,
+		Dice:          s.Dice,
+		Rolled:        s.Rolled,
+		Used:          append([]bool(nil), s.Used...),
+		Bonus:         s.Bonus,
+		Mover:         s.Mover,
+		Sixes:         s.Sixes,
+		Rules:         s.Rules,
+	}
+
+	for i, q := range s.Quadrants {
+		clone.Quadrants[i] = QuadrantState{
+			Name:      q.Name,
+			Path:      q.Path,
+			PawnNames: q.PawnNames,
+			Pawns:     append([]int(nil), q.Pawns...),
+			Team:      q.Team,
+			Captures:  q.Captures,
+		}
+	}
+
+	return clone
+}
+
+// TurnQuadrant returns the name of the quadrant having the turn
+func (s State) TurnQuadrant() string {
+	if len(s.Quadrants) == 0 {
+		return ""
+	}
+	return s.Quadrants[s.Turn].Name
+}
+
+// NextTurnQuadrant returns the name of the quadrant playing after the one having the turn
+func (s State) NextTurnQuadrant() string {
+	if len(s.Quadrants) == 0 {
+		return ""
+	}
+	return s.Quadrants[(s.Turn+1)%len(s.Quadrants)].Name
+}
+
+// SameTeam reports whether the two quadrants play together. A quadrant is always in its own team.
+func (s State) SameTeam(i int, j int) bool {
+	if i == j {
+		return true
+	}
+	return s.Rules.Teams && s.Quadrants[i].Team != "" && s.Quadrants[i].Team == s.Quadrants[j].Team
+}
+
+// Partner returns the index of the other quadrant of the team, -1 when the quadrant plays alone
+func (s State) Partner(quadrant int) int {
+	for i := range s.Quadrants {
+		if i != quadrant && s.SameTeam(i, quadrant) {
+			return i
+		}
+	}
+	return -1
+}
+
+// MovingQuadrant returns the index of the quadrant whose pawns the roll of the quadrant having the turn moves.
+// That is the partner's once the quadrant having the turn has finished and partners may roll for each other.
+func (s State) MovingQuadrant() int {
+	if s.Rules.PartnerRolls && len(s.Quadrants) > 0 && s.HasFinished(s.Turn) {
+		if partner := s.Partner(s.Turn); partner != -1 {
+			return partner
+		}
+	}
+	return s.Turn
+}
+
+// QuadrantIndex returns the index of the named quadrant, -1 if it is not playing
+func (s State) QuadrantIndex(name string) int {
+	for i, q := range s.Quadrants {
+		if q.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// PawnQuadrant returns the name of the quadrant the named pawn belongs to, empty if none does
+func (s State) PawnQuadrant(pawn string) string {
+	for _, q := range s.Quadrants {
+		if slices.Contains(q.PawnNames, pawn) {
+			return q.Name
+		}
+	}
+	return ""
+}
+
+// Position returns the board position of a pawn, -1 while it is idle
+func (s State) Position(quadrant int, pawn int) int {
+	q := s.Quadrants[quadrant]
+	if q.Pawns[pawn] < 0 {
+		return -1
+	}
+	return q.Path[q.Pawns[pawn]]
+}
+
+// IsSafe reports whether pawns on the position cannot be captured
+func (s State) IsSafe(position int) bool {
+	for _, safePosition := range s.SafePositions {
+		if safePosition == position {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFinished reports whether all pawns of the quadrant reached the end of their path
+func (s State) HasFinished(quadrant int) bool {
+	q := s.Quadrants[quadrant]
+	for _, index := range q.Pawns {
+		if index != len(q.Path)-1 {
+			return false
+		}
+	}
+	return true
+}
+
+// HasWon reports whether the quadrant and, in team games, its partner finished all their pawns
+func (s State) HasWon(quadrant int) bool {
+	for i := range s.Quadrants {
+		if s.SameTeam(i, quadrant) && !s.HasFinished(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// Winner returns the index of a quadrant that won, -1 if none did. In team games its partner won as well.
+func (s State) Winner() int {
+	for i := range s.Quadrants {
+		if s.HasWon(i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// LegalMoves returns the moves the quadrant having the turn can make with the rolled steps
+func (s State) LegalMoves(steps int) []Move {
+	var moves []Move
+
+	if len(s.Quadrants) == 0 {
+		return moves
+	}
+
+	var capturingMoves []Move
+
+	mover := s.MovingQuadrant()
+	q := s.Quadrants[mover]
+	for i := range q.Pawns {
+		target := s.targetIndex(mover, i, steps)
+		if target == -1 {
+			continue
+		}
+
+		move := Move{Pawn: i, Steps: steps}
+		moves = append(moves, move)
+
+		if s.Rules.MandatoryCapture && s.canCapture(mover, q.Path[target]) {
+			capturingMoves = append(capturingMoves, move)
+		}
+	}
+
+	if len(capturingMoves) > 0 {
+		return capturingMoves
+	}
+
+	return moves
+}
+
+// targetIndex returns the index in its path the pawn reaches with the steps, -1 if the pawn cannot move them
+func (s State) targetIndex(quadrant int, pawn int, steps int) int {
+	q := s.Quadrants[quadrant]
+	index := q.Pawns[pawn]
+	last := len(q.Path) - 1
+
+	switch {
+	case last < 0 || index == last:
+		return -1
+	case index == -1:
+		if s.Rules.IsUnlockValue(steps) {
+			return s.unlessBlocked(quadrant, -1, 0)
+		}
+		return -1
+	case index+steps <= last:
+		return s.unlessBlocked(quadrant, index, index+steps)
+	case !s.Rules.ExactFinish:
+		return s.unlessBlocked(quadrant, index, last)
+	}
+
+	return -1
+}
+
+// unlessBlocked returns the target index, or -1 when an opponent's blockade stands between the index and the target
+func (s State) unlessBlocked(quadrant int, index int, target int) int {
+	if !s.Rules.Blockades {
+		return target
+	}
+
+	path := s.Quadrants[quadrant].Path
+	for i := range s.Quadrants {
+		if s.SameTeam(i, quadrant) {
+			continue
+		}
+		for _, blockade := range s.Blockades(i) {
+			for k := index + 1; k <= target; k++ {
+				if path[k] == blockade {
+					return -1
+				}
+			}
+		}
+	}
+
+	return target
+}
+
+// Blockades returns the positions where the quadrant has two or more pawns outside the safe positions.
+// It is empty when the rules do not use blockades.
+func (s State) Blockades(quadrant int) []int {
+	var blockades []int
+
+	if !s.Rules.Blockades {
+		return blockades
+	}
+
+	counts := make(map[int]int)
+	for j := range s.Quadrants[quadrant].Pawns {
+		position := s.Position(quadrant, j)
+		if position == -1 || s.IsSafe(position) {
+			continue
+		}
+		counts[position]++
+		if counts[position] == 2 {
+			blockades = append(blockades, position)
+		}
+	}
+
+	return blockades
+}
+
+// canCapture reports whether a pawn of the quadrant landing on the position sends an opponent back
+func (s State) canCapture(quadrant int, position int) bool {
+	if s.IsSafe(position) {
+		return false
+	}
+	for i := range s.Quadrants {
+		if s.SameTeam(i, quadrant) {
+			continue
+		}
+		for j := range s.Quadrants[i].Pawns {
+			if s.Position(i, j) == position {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MovablePawns returns the names of the pawns the quadrant having the turn can move with the rolled steps
+func (s State) MovablePawns(steps int) []string {
+	var movablePawns []string
+	for _, move := range s.LegalMoves(steps) {
+		movablePawns = append(movablePawns, s.PawnName(move))
+	}
+	return movablePawns
+}
+
+// PawnName returns the name of the pawn the move moves
+func (s State) PawnName(move Move) string {
+	return s.Quadrants[s.MovingQuadrant()].PawnNames[move.Pawn]
+}
+
+// Apply plays the move for the quadrant having the turn and passes the turn when no extra turn is earned.
+// The receiver is left untouched, the returned state is a new copy.
+func (s State) Apply(move Move) (State, Outcome) {
+	next := s.Clone()
+	outcome := next.Play(move)
+	return next, outcome
+}
+
+// Play plays the move on the state itself, then passes the turn unless the move grants another roll
+func (s *State) Play(move Move) Outcome {
+	outcome := s.advance(move)
+	if !outcome.ExtraTurn {
+		s.PassTurn()
+	}
+	return outcome
+}
+
+// advance moves the pawn and captures what it lands on, leaving the turn as it is
+func (s *State) advance(move Move) Outcome {
+	outcome := Outcome{}
+
+	mover := s.MovingQuadrant()
+	q := &s.Quadrants[mover]
+	finalIndex := s.targetIndex(mover, move.Pawn, move.Steps)
+	q.Pawns[move.Pawn] = finalIndex
+
+	position := q.Path[finalIndex]
+	outcome.Finished = finalIndex == len(q.Path)-1
+
+	if !s.IsSafe(position) {
+		for i := range s.Quadrants {
+			if s.SameTeam(i, mover) {
+				continue
+			}
+			for j := range s.Quadrants[i].Pawns {
+				if s.Position(i, j) == position {
+					s.Quadrants[i].Pawns[j] = -1
+					outcome.CapturedPawns = append(outcome.CapturedPawns, s.Quadrants[i].PawnNames[j])
+				}
+			}
+		}
+	}
+
+	q.Captures += len(outcome.CapturedPawns)
+
+	outcome.ExtraTurn = s.Rules.KeepsTurn(move.Steps) ||
+		(len(outcome.CapturedPawns) > 0 && s.Rules.BonusOnCapture) ||
+		(outcome.Finished && s.Rules.BonusOnFinish)
+
+	return outcome
+}
+
+// Pass returns the state after a roll that allowed no move
+func (s State) Pass(steps int) State {
+	next := s.Clone()
+	if !s.Rules.KeepsTurn(steps) {
+		next.PassTurn()
+	}
+	return next
+}
+
+// PassTurn gives the turn to the next playing quadrant
+func (s *State) PassTurn() {
+	for i := range s.Quadrants {
+		s.Turn = (s.Turn + 1) % len(s.Quadrants)
+
+		// In team games a quadrant that finished is skipped, unless it rolls for its partner
+		if i == len(s.Quadrants)-1 || !s.Rules.Teams || s.Rules.PartnerRolls || !s.HasFinished(s.Turn) {
+			break
+		}
+	}
+	s.Sixes = 0
+}
+
+// IndexInPath returns the index of the position in the path, -1 if the position is not on it
+func IndexInPath(path []int, position int) int {
+	if position == -1 {
+		return -1
+	}
+	for i, pathPosition := range path {
+		if pathPosition == position {
+			return i
+		}
+	}
+	return -1
+}
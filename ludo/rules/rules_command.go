@@ -0,0 +1,370 @@
+package rules
+
+import (
+	"errors"
+	"slices"
+)
+
+// Phase is what the quadrant having the turn has to do next
+type Phase string
+
+const (
+	PHASE_ROLL          Phase = "ROLL"
+	PHASE_MOVE          Phase = "MOVE"
+	PHASE_COMPLETE_TURN Phase = "COMPLETE_TURN"
+	PHASE_OVER          Phase = "OVER"
+)
+
+var (
+	ErrGameOver          = errors.New("game is over")
+	ErrNotYourTurn       = errors.New("quadrant does not have the turn")
+	ErrUnexpectedCommand = errors.New("command is not expected at this point of the turn")
+	ErrInvalidDiceValue  = errors.New("dice value must be between 1 and 6")
+	ErrInvalidSteps      = errors.New("steps do not match the rolled dice")
+	ErrUnknownPawn       = errors.New("pawn does not belong to the quadrant")
+	ErrDieUsed           = errors.New("die was already moved with")
+	ErrIllegalMove       = errors.New("pawn cannot move with the rolled dice")
+)
+
+// Command is an action a quadrant takes on the game
+type Command interface {
+	isCommand()
+}
+
+// RollDice records a roll of the quadrant having the turn. The value is rolled by the caller, so the rules stay deterministic.
+type RollDice struct {
+	Quadrant string
+	Value    int
+	Second   int // Second die, two-dice games only
+}
+
+// MovePawn moves a pawn of the quadrant having the turn by the rolled steps
+type MovePawn struct {
+	Quadrant string
+	Pawn     string
+	Steps    int
+	Die      int // Index of the die the move uses in two-dice games
+}
+
+// CompleteTurn tells that the quadrant that moved last is done with its move
+type CompleteTurn struct {
+	Quadrant string
+}
+
+// TimeUp ends a timed game, whatever the phase, and ranks the quadrants by score
+type TimeUp struct{}
+
+func (RollDice) isCommand()     {}
+func (MovePawn) isCommand()     {}
+func (CompleteTurn) isCommand() {}
+func (TimeUp) isCommand()       {}
+
+// Event is something that happened in the game as the result of a command
+type Event interface {
+	isEvent()
+}
+
+// DiceRolled is emitted for every roll, with the pawns the roll can move
+type DiceRolled struct {
+	Quadrant     string
+	Value        int
+	MovablePawns []string
+	Forfeited    bool // The roll was the third 6 in a row and ended the turn
+
+	// Two-dice games only
+	Values            []int      // Both dice
+	MovablePawnsByDie [][]string // Pawns each die can move, MovablePawns holds all of them
+}
+
+// PawnMoved is emitted for every move. An illegal move leaves the pawn where it was.
+type PawnMoved struct {
+	Quadrant        string // Quadrant of the pawn
+	RolledBy        string // Quadrant having the turn, another one than Quadrant when a finished partner rolled
+	Pawn            string
+	Steps           int
+	InitialPosition int // -1 when the pawn was idle
+	InitialIndex    int // -1 when the pawn was idle
+	FinalPosition   int
+	FinalIndex      int
+	Finished        bool // The pawn reached the end of its path
+	CapturedPawns   []string
+	Die             int   // Index of the die the move used
+	RemainingDice   []int // Indexes of the dice still to move with, in two-dice games
+}
+
+// TurnStarted is emitted when a quadrant has to roll
+type TurnStarted struct {
+	Quadrant string
+}
+
+// GameWon is emitted when a quadrant finishes all its pawns, or in team games when both partners did.
+// Timed games also end when the time is up, the top scorer then wins.
+type GameWon struct {
+	Quadrants  []string
+	TimeUp     bool    // The game ended on time rather than by finishing the pawns
+	Scoreboard []Score // Final ranking, only for timed games
+}
+
+func (DiceRolled) isEvent()  {}
+func (PawnMoved) isEvent()   {}
+func (TurnStarted) isEvent() {}
+func (GameWon) isEvent()     {}
+
+// Execute applies the command to the state.
+// It returns the new state and the events the command produced, the given state is never changed.
+// A rejected command returns the given state, no events and the reason.
+func Execute(s State, command Command) (State, []Event, error) {
+	if s.Phase == PHASE_OVER {
+		return s, nil, ErrGameOver
+	}
+
+	next := s.Clone()
+
+	var events []Event
+	var err error
+
+	switch c := command.(type) {
+	case RollDice:
+		events, err = next.rollDice(c)
+	case MovePawn:
+		events, err = next.movePawn(c)
+	case CompleteTurn:
+		events, err = next.completeTurn(c)
+	case TimeUp:
+		events, err = next.timeUp()
+	default:
+		err = ErrUnexpectedCommand
+	}
+
+	if err != nil {
+		return s, nil, err
+	}
+
+	return next, events, nil
+}
+
+func (s *State) rollDice(c RollDice) ([]Event, error) {
+	if err := s.expect(PHASE_ROLL, c.Quadrant); err != nil {
+		return nil, err
+	}
+
+	values := []int{c.Value}
+	if s.Rules.TwoDice {
+		values = append(values, c.Second)
+	}
+
+	for _, value := range values {
+		if value < 1 || value > 6 {
+			return nil, ErrInvalidDiceValue
+		}
+	}
+
+	s.Dice = c.Value
+	s.Rolled = values
+	s.Used = make([]bool, len(values))
+	s.Bonus = s.Rules.KeepsTurn(c.Value) || (s.Rules.TwoDice && c.Value == c.Second)
+
+	if c.Value == 6 {
+		s.Sixes++
+	} else {
+		s.Sixes = 0
+	}
+
+	if s.Rules.ThreeSixesForfeit && s.Sixes == 3 {
+		// The third 6 in a row moves nothing and ends the turn
+		s.PassTurn()
+		return []Event{
+			DiceRolled{Quadrant: c.Quadrant, Value: c.Value, Forfeited: true},
+			TurnStarted{Quadrant: s.TurnQuadrant()},
+		}, nil
+	}
+
+	diceRolled := DiceRolled{
+		Quadrant:     c.Quadrant,
+		Value:        c.Value,
+		MovablePawns: s.MovablePawns(c.Value),
+	}
+
+	if s.Rules.TwoDice {
+		diceRolled.Values = values
+		diceRolled.MovablePawns = nil
+		for _, value := range values {
+			movablePawns := s.MovablePawns(value)
+			diceRolled.MovablePawnsByDie = append(diceRolled.MovablePawnsByDie, movablePawns)
+			for _, name := range movablePawns {
+				if !contains(diceRolled.MovablePawns, name) {
+					diceRolled.MovablePawns = append(diceRolled.MovablePawns, name)
+				}
+			}
+		}
+	}
+
+	events := []Event{diceRolled}
+
+	if len(diceRolled.MovablePawns) > 0 {
+		s.Phase = PHASE_MOVE
+		return events, nil
+	}
+
+	// Nothing can move, the roll may still grant another one
+	if !s.Bonus {
+		s.PassTurn()
+	}
+
+	return append(events, TurnStarted{Quadrant: s.TurnQuadrant()}), nil
+}
+
+func (s *State) movePawn(c MovePawn) ([]Event, error) {
+	// A player rolling for the partner may name either quadrant
+	mover := s.MovingQuadrant()
+	if s.Phase == PHASE_MOVE && c.Quadrant == s.Quadrants[mover].Name {
+		c.Quadrant = s.TurnQuadrant()
+	}
+
+	if err := s.expect(PHASE_MOVE, c.Quadrant); err != nil {
+		return nil, err
+	}
+
+	die := 0
+	if s.Rules.TwoDice {
+		die = c.Die
+	}
+
+	if die < 0 || die >= len(s.Rolled) || c.Steps != s.Rolled[die] {
+		return nil, ErrInvalidSteps
+	}
+
+	if s.Used[die] {
+		return nil, ErrDieUsed
+	}
+
+	q := s.Quadrants[mover]
+
+	pawnIndex := -1
+	for i, name := range q.PawnNames {
+		if name == c.Pawn {
+			pawnIndex = i
+			break
+		}
+	}
+	if pawnIndex == -1 {
+		return nil, ErrUnknownPawn
+	}
+
+	event := PawnMoved{
+		Quadrant:        q.Name,
+		RolledBy:        c.Quadrant,
+		Pawn:            c.Pawn,
+		Steps:           c.Steps,
+		InitialPosition: s.Position(mover, pawnIndex),
+		InitialIndex:    q.Pawns[pawnIndex],
+		Die:             die,
+	}
+
+	move := Move{Pawn: pawnIndex, Steps: c.Steps}
+
+	// The state is left as it was, so the player can pick another pawn
+	if !slices.Contains(s.LegalMoves(c.Steps), move) {
+		return nil, ErrIllegalMove
+	}
+
+	s.Mover = s.Turn
+	s.Phase = PHASE_COMPLETE_TURN
+	s.Used[die] = true
+
+	outcome := s.advance(move)
+	s.Bonus = s.Bonus || outcome.ExtraTurn
+
+	event.FinalPosition = s.Position(mover, pawnIndex)
+	event.FinalIndex = s.Quadrants[mover].Pawns[pawnIndex]
+	event.Finished = outcome.Finished
+	event.CapturedPawns = outcome.CapturedPawns
+
+	if s.HasWon(mover) {
+		return []Event{event, s.endGame(mover, false)}, nil
+	}
+
+	event.RemainingDice = s.RemainingDice()
+	if len(event.RemainingDice) > 0 {
+		// The other die moves before the turn completes
+		s.Phase = PHASE_MOVE
+		return []Event{event}, nil
+	}
+
+	if !s.Bonus {
+		s.PassTurn()
+	}
+
+	return []Event{event}, nil
+}
+
+// RemainingDice returns the indexes of the dice of the last roll not moved with yet that can still move a pawn
+func (s State) RemainingDice() []int {
+	var remaining []int
+	for i, value := range s.Rolled {
+		if !s.Used[i] && len(s.MovablePawns(value)) > 0 {
+			remaining = append(remaining, i)
+		}
+	}
+	return remaining
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *State) timeUp() ([]Event, error) {
+	if !s.Rules.IsTimed() {
+		return nil, ErrUnexpectedCommand
+	}
+
+	return []Event{s.endGame(s.Scoreboard()[0].Quadrant, true)}, nil
+}
+
+// endGame ends the game with the quadrant and its partner as winners
+func (s *State) endGame(winner int, timeUp bool) GameWon {
+	s.Phase = PHASE_OVER
+
+	gameWon := GameWon{TimeUp: timeUp}
+	for i, q := range s.Quadrants {
+		if s.SameTeam(i, winner) {
+			gameWon.Quadrants = append(gameWon.Quadrants, q.Name)
+		}
+	}
+
+	if s.Rules.IsTimed() {
+		gameWon.Scoreboard = s.Scoreboard()
+	}
+
+	return gameWon
+}
+
+func (s *State) completeTurn(c CompleteTurn) ([]Event, error) {
+	if s.Phase != PHASE_COMPLETE_TURN {
+		return nil, ErrUnexpectedCommand
+	}
+
+	if s.Quadrants[s.Mover].Name != c.Quadrant {
+		return nil, ErrNotYourTurn
+	}
+
+	s.Phase = PHASE_ROLL
+
+	return []Event{TurnStarted{Quadrant: s.TurnQuadrant()}}, nil
+}
+
+// expect checks that the game waits for the phase from the quadrant having the turn
+func (s *State) expect(phase Phase, quadrant string) error {
+	if s.Phase != phase {
+		return ErrUnexpectedCommand
+	}
+	if s.TurnQuadrant() != quadrant {
+		return ErrNotYourTurn
+	}
+	return nil
+}
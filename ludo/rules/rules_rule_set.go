@@ -0,0 +1,138 @@
+package rules
+
+import (
+	"fmt"
+)
+
+// RuleSet holds the house rules a board is played with
+type RuleSet struct {
+	Name              string `bson:"name" json:"name"`
+	UnlockValues      []int  `bson:"unlockValues" json:"unlockValues"`           // Rolls that bring an idle pawn on its path
+	ThreeSixesForfeit bool   `bson:"threeSixesForfeit" json:"threeSixesForfeit"` // A third 6 in a row ends the turn without a move
+	BonusOnSix        bool   `bson:"bonusOnSix" json:"bonusOnSix"`               // Rolling a 6 grants another roll
+	BonusOnCapture    bool   `bson:"bonusOnCapture" json:"bonusOnCapture"`       // Capturing a pawn grants another roll
+	BonusOnFinish     bool   `bson:"bonusOnFinish" json:"bonusOnFinish"`         // Reaching the end of the path grants another roll
+	ExactFinish       bool   `bson:"exactFinish" json:"exactFinish"`             // A pawn needs the exact roll to reach the end of its path
+	MandatoryCapture  bool   `bson:"mandatoryCapture" json:"mandatoryCapture"`   // When a move can capture, only capturing moves are allowed
+	Blockades         bool   `bson:"blockades" json:"blockades"`                 // Two pawns of a quadrant on a square that is not safe bar opponents from passing or landing on it
+	Teams             bool   `bson:"teams" json:"teams"`                         // Opposite quadrants play as a team and win together, four-player boards only
+	PartnerRolls      bool   `bson:"partnerRolls" json:"partnerRolls"`           // A team player who finished rolls for the partner
+	TimeLimit         int    `bson:"timeLimit" json:"timeLimit"`                 // Minutes after which the board ends by score, 0 for untimed boards
+	TwoDice           bool   `bson:"twoDice" json:"twoDice"`                     // Every roll throws two dice, each moves a pawn and doubles grant another roll
+}
+
+const (
+	CLASSIC    = "CLASSIC"
+	RELAXED    = "RELAXED"
+	TOURNAMENT = "TOURNAMENT"
+	TEAMS      = "TEAMS"
+	QUICK      = "QUICK"
+	TWO_DICE   = "TWO_DICE"
+)
+
+// CLASSIC_RULES are the rules the boards were always played with
+var CLASSIC_RULES = RuleSet{
+	Name:           CLASSIC,
+	UnlockValues:   []int{6},
+	BonusOnSix:     true,
+	BonusOnCapture: true,
+	BonusOnFinish:  true,
+	ExactFinish:    true,
+}
+
+// RULE_SETS are the presets boards can be created with, by name
+var RULE_SETS = map[string]RuleSet{
+	CLASSIC: CLASSIC_RULES,
+	RELAXED: {
+		Name:           RELAXED,
+		UnlockValues:   []int{1, 6},
+		BonusOnSix:     true,
+		BonusOnCapture: true,
+		BonusOnFinish:  true,
+	},
+	TOURNAMENT: {
+		Name:              TOURNAMENT,
+		UnlockValues:      []int{6},
+		ThreeSixesForfeit: true,
+		BonusOnSix:        true,
+		BonusOnCapture:    true,
+		ExactFinish:       true,
+		MandatoryCapture:  true,
+		Blockades:         true,
+	},
+	TEAMS: {
+		Name:           TEAMS,
+		UnlockValues:   []int{6},
+		BonusOnSix:     true,
+		BonusOnCapture: true,
+		BonusOnFinish:  true,
+		ExactFinish:    true,
+		Teams:          true,
+		PartnerRolls:   true,
+	},
+	QUICK: {
+		Name:           QUICK,
+		UnlockValues:   []int{6},
+		BonusOnSix:     true,
+		BonusOnCapture: true,
+		BonusOnFinish:  true,
+		ExactFinish:    true,
+		TimeLimit:      10,
+	},
+	TWO_DICE: {
+		Name:           TWO_DICE,
+		UnlockValues:   []int{6},
+		BonusOnCapture: true,
+		BonusOnFinish:  true,
+		ExactFinish:    true,
+		TwoDice:        true,
+	},
+}
+
+// GetRuleSet returns the preset with the given name
+func GetRuleSet(name string) (RuleSet, error) {
+	ruleSet, ok := RULE_SETS[name]
+	if !ok {
+		return RuleSet{}, fmt.Errorf("unknown rule set %s", name)
+	}
+	return ruleSet, nil
+}
+
+// Validate checks that the rules can be played
+func (r RuleSet) Validate() error {
+	if len(r.UnlockValues) == 0 {
+		return fmt.Errorf("rule set %s has no unlock value", r.Name)
+	}
+	for _, value := range r.UnlockValues {
+		if value < 1 || value > 6 {
+			return fmt.Errorf("rule set %s has the invalid unlock value %d", r.Name, value)
+		}
+	}
+	if r.TwoDice && r.ThreeSixesForfeit {
+		return fmt.Errorf("rule set %s forfeits on three sixes, which only applies to one die", r.Name)
+	}
+	if r.TimeLimit < 0 {
+		return fmt.Errorf("rule set %s has the negative time limit %d", r.Name, r.TimeLimit)
+	}
+	return nil
+}
+
+// IsUnlockValue reports whether the roll brings an idle pawn on its path
+func (r RuleSet) IsUnlockValue(steps int) bool {
+	for _, value := range r.UnlockValues {
+		if value == steps {
+			return true
+		}
+	}
+	return false
+}
+
+// KeepsTurn reports whether the roll grants another roll, whatever the move. With two dice only doubles do.
+func (r RuleSet) KeepsTurn(steps int) bool {
+	return steps == 6 && r.BonusOnSix && !r.TwoDice
+}
+
+// IsTimed reports whether the board ends by score once the time limit is over
+func (r RuleSet) IsTimed() bool {
+	return r.TimeLimit > 0
+}
@@ -0,0 +1,113 @@
+package rules
+
+import (
+	"sort"
+)
+
+const (
+	FINISH_BONUS  = 25 // Points for every pawn that reached the end of its path
+	CAPTURE_BONUS = 10 // Points for every opponent pawn sent back
+)
+
+// Score is the standing of a quadrant in a timed game
+type Score struct {
+	Quadrant      int // Index of the quadrant in the state
+	Rank          int // 1 for the top scorer
+	Progress      int // Squares covered by the pawns, entering the board counts as one
+	FinishedPawns int
+	Captures      int
+	Total         int
+	TeamTotal     int // Total of the quadrant and its partner, the quadrant's own total without teams
+	farthest      int // Index of the most advanced pawn, breaks the ties
+}
+
+// Score returns the standing of the quadrant, its rank left unset
+func (s State) Score(quadrant int) Score {
+	q := s.Quadrants[quadrant]
+
+	score := Score{
+		Quadrant: quadrant,
+		Captures: q.Captures,
+		farthest: -1,
+	}
+
+	for _, index := range q.Pawns {
+		score.Progress += index + 1
+		if index == len(q.Path)-1 {
+			score.FinishedPawns++
+		}
+		if index > score.farthest {
+			score.farthest = index
+		}
+	}
+
+	score.Total = score.Progress + score.FinishedPawns*FINISH_BONUS + score.Captures*CAPTURE_BONUS
+
+	return score
+}
+
+// Scoreboard ranks the quadrants by total score, summed per team in team games so partners rank together.
+// Ties go to the side with more finished pawns, then more captures, then the most advanced pawn and,
+// when everything is equal, to the earliest in turn order.
+func (s State) Scoreboard() []Score {
+	scores := make([]Score, len(s.Quadrants))
+	for i := range s.Quadrants {
+		scores[i] = s.Score(i)
+	}
+
+	// Every quadrant is ranked by the standing of its team, its own standing only orders the partners
+	teams := make([]Score, len(scores))
+	for i := range scores {
+		teams[i] = Score{Quadrant: i, farthest: -1}
+		for j := range scores {
+			if !s.SameTeam(i, j) {
+				continue
+			}
+			teams[i].Total += scores[j].Total
+			teams[i].FinishedPawns += scores[j].FinishedPawns
+			teams[i].Captures += scores[j].Captures
+			if scores[j].farthest > teams[i].farthest {
+				teams[i].farthest = scores[j].farthest
+			}
+			if j < teams[i].Quadrant {
+				teams[i].Quadrant = j
+			}
+		}
+		scores[i].TeamTotal = teams[i].Total
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		a, b := teams[scores[i].Quadrant], teams[scores[j].Quadrant]
+		if a.Quadrant != b.Quadrant {
+			if above, decided := ranksAbove(a, b); decided {
+				return above
+			}
+			return a.Quadrant < b.Quadrant
+		}
+		above, _ := ranksAbove(scores[i], scores[j])
+		return above
+	})
+
+	for i := range scores {
+		scores[i].Rank = i + 1
+	}
+
+	return scores
+}
+
+// ranksAbove reports whether a ranks above b, decided is false when they are even
+func ranksAbove(a Score, b Score) (above bool, decided bool) {
+	if a.Total != b.Total {
+		return a.Total > b.Total, true
+	}
+	if a.FinishedPawns != b.FinishedPawns {
+		return a.FinishedPawns > b.FinishedPawns, true
+	}
+	if a.Captures != b.Captures {
+		return a.Captures > b.Captures, true
+	}
+	if a.farthest != b.farthest {
+		return a.farthest > b.farthest, true
+	}
+	return false, false
+}
@@ -0,0 +1,265 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestState() State {
+	return State{
+		SafePositions: []int{1},
+		Quadrants: []QuadrantState{
+			{Name: "QUADRANT_1", Path: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, PawnNames: []string{"Q1_P1", "Q1_P2"}, Pawns: []int{-1, 2}},
+			{Name: "QUADRANT_2", Path: []int{6, 7, 8, 9, 10, 1, 2, 3, 4, 20}, PawnNames: []string{"Q2_P1", "Q2_P2"}, Pawns: []int{-1, 0}},
+		},
+		Phase: PHASE_ROLL,
+		Rules: CLASSIC_RULES,
+	}
+}
+
+func TestLegalMovesUnlockOnlyOnSix(t *testing.T) {
+	state := newTestState()
+
+	assert.Equal(t, []Move{{Pawn: 1, Steps: 3}}, state.LegalMoves(3), "Idle pawns should not move without a 6")
+	assert.Len(t, state.LegalMoves(6), 2, "A 6 should unlock the idle pawn")
+	assert.Empty(t, State{Quadrants: []QuadrantState{{Path: []int{1, 2}, Pawns: []int{1}}}}.LegalMoves(1), "Finished pawns should not move")
+}
+
+func TestApplyCapturesAndKeepsTurn(t *testing.T) {
+	state := newTestState()
+
+	next, outcome := state.Apply(Move{Pawn: 1, Steps: 3})
+
+	assert.Equal(t, []string{"Q2_P2"}, outcome.CapturedPawns, "Landing on an opponent outside a safe position should capture it")
+	assert.True(t, outcome.ExtraTurn, "A capture should grant another turn")
+	assert.Equal(t, -1, next.Quadrants[1].Pawns[1], "The captured pawn should be idle again")
+	assert.Equal(t, 0, state.Quadrants[1].Pawns[1], "Apply should not change the original state")
+}
+
+func TestExecutePlaysATurn(t *testing.T) {
+	state := newTestState()
+
+	state, events, err := Execute(state, RollDice{Quadrant: "QUADRANT_1", Value: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{DiceRolled{Quadrant: "QUADRANT_1", Value: 2, MovablePawns: []string{"Q1_P2"}}}, events)
+	assert.Equal(t, PHASE_MOVE, state.Phase)
+
+	_, _, err = Execute(state, MovePawn{Quadrant: "QUADRANT_1", Pawn: "Q1_P2", Steps: 5})
+	assert.ErrorIs(t, err, ErrInvalidSteps, "Steps should match the roll")
+
+	_, _, err = Execute(state, MovePawn{Quadrant: "QUADRANT_1", Pawn: "Q1_P1", Steps: 2})
+	assert.ErrorIs(t, err, ErrIllegalMove, "An idle pawn should not move without a six")
+
+	state, events, err = Execute(state, MovePawn{Quadrant: "QUADRANT_1", Pawn: "Q1_P2", Steps: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{PawnMoved{Quadrant: "QUADRANT_1", RolledBy: "QUADRANT_1", Pawn: "Q1_P2", Steps: 2, InitialPosition: 3, InitialIndex: 2, FinalPosition: 5, FinalIndex: 4}}, events)
+	assert.Equal(t, "QUADRANT_2", state.TurnQuadrant(), "The turn should pass without a bonus")
+
+	_, _, err = Execute(state, CompleteTurn{Quadrant: "QUADRANT_2"})
+	assert.ErrorIs(t, err, ErrNotYourTurn, "Only the quadrant that moved completes the turn")
+
+	state, events, err = Execute(state, CompleteTurn{Quadrant: "QUADRANT_1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{TurnStarted{Quadrant: "QUADRANT_2"}}, events)
+	assert.Equal(t, PHASE_ROLL, state.Phase)
+}
+
+func TestExecutePassesTurnWithoutMovablePawns(t *testing.T) {
+	state := newTestState()
+	state.Quadrants[0].Pawns = []int{-1, 9}
+
+	next, events, err := Execute(state, RollDice{Quadrant: "QUADRANT_1", Value: 4})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{DiceRolled{Quadrant: "QUADRANT_1", Value: 4}, TurnStarted{Quadrant: "QUADRANT_2"}}, events)
+	assert.Equal(t, PHASE_ROLL, next.Phase)
+	assert.Equal(t, 0, state.Turn, "Execute should not change the original state")
+}
+
+func TestExecuteEndsGameWhenAllPawnsFinish(t *testing.T) {
+	state := newTestState()
+	state.Quadrants[0].Pawns = []int{9, 7}
+
+	state, _, _ = Execute(state, RollDice{Quadrant: "QUADRANT_1", Value: 2})
+	state, events, err := Execute(state, MovePawn{Quadrant: "QUADRANT_1", Pawn: "Q1_P2", Steps: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, GameWon{Quadrants: []string{"QUADRANT_1"}}, events[len(events)-1])
+	assert.Equal(t, PHASE_OVER, state.Phase)
+
+	_, _, err = Execute(state, CompleteTurn{Quadrant: "QUADRANT_1"})
+	assert.ErrorIs(t, err, ErrGameOver)
+}
+
+func TestRuleSetVariants(t *testing.T) {
+	state := newTestState()
+	state.Rules = RULE_SETS[RELAXED]
+	state.Quadrants[0].Pawns = []int{-1, 8}
+
+	assert.Len(t, state.LegalMoves(1), 2, "Relaxed rules should unlock on a 1")
+
+	next, outcome := state.Apply(Move{Pawn: 1, Steps: 4})
+	assert.True(t, outcome.Finished, "Relaxed rules should let a pawn overshoot the end of its path")
+	assert.Equal(t, 9, next.Quadrants[0].Pawns[1])
+
+	state = newTestState()
+	state.Rules = RULE_SETS[TOURNAMENT]
+	state.Quadrants[0].Pawns = []int{0, 2}
+
+	assert.Equal(t, []Move{{Pawn: 1, Steps: 3}}, state.LegalMoves(3), "Capturing should be mandatory")
+
+	state.Rules.BonusOnCapture = false
+	_, outcome = state.Apply(Move{Pawn: 1, Steps: 3})
+	assert.False(t, outcome.ExtraTurn, "A capture should not grant a roll without the bonus")
+}
+
+func TestThreeSixesForfeitTheTurn(t *testing.T) {
+	state := newTestState()
+	state.Rules = RULE_SETS[TOURNAMENT]
+	state.Sixes = 2
+
+	next, events, err := Execute(state, RollDice{Quadrant: "QUADRANT_1", Value: 6})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{DiceRolled{Quadrant: "QUADRANT_1", Value: 6, Forfeited: true}, TurnStarted{Quadrant: "QUADRANT_2"}}, events)
+	assert.Equal(t, 0, next.Sixes)
+}
+
+func TestBlockadeBarsOpponents(t *testing.T) {
+	state := newTestState()
+	state.Rules.Blockades = true
+	state.Quadrants[1].Pawns = []int{2, 2} // Both pawns of QUADRANT_2 on position 8
+	state.Quadrants[0].Pawns = []int{6, 3} // QUADRANT_1 pawns on positions 7 and 4
+
+	assert.Equal(t, []int{8}, state.Blockades(1))
+	assert.Equal(t, []Move{{Pawn: 1, Steps: 1}}, state.LegalMoves(1), "A pawn should not land on a blockade")
+	assert.Equal(t, []Move{{Pawn: 1, Steps: 2}}, state.LegalMoves(2), "A pawn should not pass a blockade")
+
+	state.Rules.Blockades = false
+	assert.Len(t, state.LegalMoves(2), 2, "Without the rule the pawns should pass")
+}
+
+func TestBlockadeOfATeammateDoesNotBar(t *testing.T) {
+	state := newTestState()
+	state.Rules = RULE_SETS[TEAMS]
+	state.Rules.Blockades = true
+	state.Quadrants[1].Pawns = []int{2, 2} // Both pawns of QUADRANT_2 on position 8
+	state.Quadrants[0].Pawns = []int{6, 3} // QUADRANT_1 pawns on positions 7 and 4
+
+	assert.Equal(t, []Move{{Pawn: 1, Steps: 2}}, state.LegalMoves(2), "A pawn should not pass the blockade of an opponent")
+
+	state.Quadrants[0].Team = "TEAM_1"
+	state.Quadrants[1].Team = "TEAM_1"
+	assert.Len(t, state.LegalMoves(2), 2, "A pawn should pass the blockade of its teammate")
+
+	state.Quadrants[1].Pawns = []int{5, 5} // Both pawns of QUADRANT_2 on the safe position 1
+	state.Quadrants[1].Team = "TEAM_2"
+	state.Quadrants[0].Pawns = []int{-1, 3}
+	assert.Len(t, state.LegalMoves(6), 2, "Pawns on a safe position should not form a blockade")
+}
+
+func TestTeamsShareTheBoardAndWinTogether(t *testing.T) {
+	state := newTestState()
+	state.Rules = RULE_SETS[TEAMS]
+	state.Quadrants[0].Team = "TEAM_1"
+	state.Quadrants[1].Team = "TEAM_1"
+
+	next, outcome := state.Apply(Move{Pawn: 1, Steps: 3})
+	assert.Empty(t, outcome.CapturedPawns, "Teammates should not capture each other")
+	assert.Equal(t, 0, next.Quadrants[1].Pawns[1])
+
+	state.Quadrants[0].Pawns = []int{9, 9}
+	assert.Equal(t, -1, state.Winner(), "The team should win only when both partners finished")
+	assert.Equal(t, 1, state.MovingQuadrant(), "A finished player should roll for the partner")
+
+	state.Quadrants[1].Pawns = []int{9, 8}
+	state, _, _ = Execute(state, RollDice{Quadrant: "QUADRANT_1", Value: 1})
+	state, events, err := Execute(state, MovePawn{Quadrant: "QUADRANT_1", Pawn: "Q2_P2", Steps: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, GameWon{Quadrants: []string{"QUADRANT_1", "QUADRANT_2"}}, events[len(events)-1])
+	assert.Equal(t, PHASE_OVER, state.Phase)
+}
+
+func TestTimeUpRanksByScore(t *testing.T) {
+	state := newTestState()
+	state.Rules = RULE_SETS[QUICK]
+	state.Quadrants[0].Pawns = []int{-1, 3} // 4 squares covered
+	state.Quadrants[1].Pawns = []int{1, 1}  // 4 squares covered too, but farther back
+
+	_, _, err := Execute(newTestState(), TimeUp{})
+	assert.ErrorIs(t, err, ErrUnexpectedCommand, "Untimed games should not end on time")
+
+	next, events, err := Execute(state, TimeUp{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, PHASE_OVER, next.Phase)
+	gameWon := events[0].(GameWon)
+	assert.True(t, gameWon.TimeUp)
+	assert.Equal(t, []string{"QUADRANT_1"}, gameWon.Quadrants, "The most advanced pawn should break the tie")
+	assert.Equal(t, 4, gameWon.Scoreboard[1].Total)
+
+	state.Quadrants[1].Captures = 1
+	assert.Equal(t, 1, state.Scoreboard()[0].Quadrant, "Captures should add to the score")
+}
+
+func TestTimeUpRanksTeamsBySummedScore(t *testing.T) {
+	path := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	state := State{
+		Quadrants: []QuadrantState{
+			{Name: "QUADRANT_1", Team: "TEAM_1", Path: path, PawnNames: []string{"Q1_P1"}, Pawns: []int{7}}, // 8 squares covered
+			{Name: "QUADRANT_2", Team: "TEAM_2", Path: path, PawnNames: []string{"Q2_P1"}, Pawns: []int{4}}, // 5 squares covered
+			{Name: "QUADRANT_3", Team: "TEAM_1", Path: path, PawnNames: []string{"Q3_P1"}, Pawns: []int{-1}},
+			{Name: "QUADRANT_4", Team: "TEAM_2", Path: path, PawnNames: []string{"Q4_P1"}, Pawns: []int{4}}, // 5 squares covered
+		},
+		Phase: PHASE_ROLL,
+		Rules: RULE_SETS[TEAMS],
+	}
+	state.Rules.TimeLimit = 10
+
+	_, events, err := Execute(state, TimeUp{})
+
+	assert.NoError(t, err)
+	gameWon := events[0].(GameWon)
+	assert.Equal(t, []string{"QUADRANT_2", "QUADRANT_4"}, gameWon.Quadrants, "The team with the higher summed score should win")
+
+	var ranked []string
+	for _, score := range gameWon.Scoreboard {
+		ranked = append(ranked, state.Quadrants[score.Quadrant].Name)
+	}
+	assert.Equal(t, []string{"QUADRANT_2", "QUADRANT_4", "QUADRANT_1", "QUADRANT_3"}, ranked, "Partners should rank together")
+	assert.Equal(t, 10, gameWon.Scoreboard[0].TeamTotal)
+	assert.Equal(t, 8, gameWon.Scoreboard[2].TeamTotal)
+}
+
+func TestTwoDiceMoveTwoPawns(t *testing.T) {
+	state := newTestState()
+	state.Rules = RULE_SETS[TWO_DICE]
+	state.Quadrants[1].Pawns = []int{-1, 9}
+
+	state, events, err := Execute(state, RollDice{Quadrant: "QUADRANT_1", Value: 6, Second: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Q1_P1", "Q1_P2"}, {"Q1_P2"}}, events[0].(DiceRolled).MovablePawnsByDie)
+
+	_, _, err = Execute(state, MovePawn{Quadrant: "QUADRANT_1", Pawn: "Q1_P1", Steps: 6, Die: 1})
+	assert.ErrorIs(t, err, ErrInvalidSteps, "The steps should match the chosen die")
+
+	state, events, err = Execute(state, MovePawn{Quadrant: "QUADRANT_1", Pawn: "Q1_P1", Steps: 6, Die: 0})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, events[0].(PawnMoved).RemainingDice)
+	assert.Equal(t, PHASE_MOVE, state.Phase, "The second die should move before the turn completes")
+
+	_, _, err = Execute(state, MovePawn{Quadrant: "QUADRANT_1", Pawn: "Q1_P1", Steps: 6, Die: 0})
+	assert.ErrorIs(t, err, ErrDieUsed)
+
+	state, events, err = Execute(state, MovePawn{Quadrant: "QUADRANT_1", Pawn: "Q1_P2", Steps: 1, Die: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, events[0].(PawnMoved).RemainingDice)
+	assert.Equal(t, "QUADRANT_2", state.TurnQuadrant(), "A six should not keep the turn with two dice")
+
+	state, _, _ = Execute(state, CompleteTurn{Quadrant: "QUADRANT_1"})
+	state, _, _ = Execute(state, RollDice{Quadrant: "QUADRANT_2", Value: 2, Second: 2})
+	assert.Equal(t, "QUADRANT_2", state.TurnQuadrant(), "Doubles should grant another roll even when nothing moves")
+}
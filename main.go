@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 	"ludo"
@@ -9,12 +10,20 @@ import (
 	"messaging/socket"
 	"metagame/gameserver/config"
 	"metagame/gameserver/helpers"
+	"os"
+	"os/signal"
+	"snakes"
 	"strings"
 	"sync"
+	"syscall"
+	"time"
 )
 
 var gameServiceMap = make(map[string]common.GameService)
 
+// DEFAULT_SHUTDOWN_DEADLINE is the time the server has to drain when the configured one is invalid
+const DEFAULT_SHUTDOWN_DEADLINE = 30 * time.Second
+
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
@@ -45,14 +54,40 @@ func main() {
 		log.Fatalf("Failed to connect to MongoDB: %v", err)
 	}
 
+	switch policy := socket.SlowConsumerPolicy(cfg.SlowConsumer); policy {
+	case socket.SLOW_CONSUMER_DROP, socket.SLOW_CONSUMER_COALESCE, socket.SLOW_CONSUMER_DISCONNECT:
+		socket.SlowConsumer = policy
+	default:
+		log.Printf("Invalid slow consumer policy %s, using %s", policy, socket.SlowConsumer)
+	}
+	if cfg.SendQueueSize > 0 {
+		socket.SendQueueSize = cfg.SendQueueSize
+	}
+	switch policy := socket.DuplicateSessionPolicy(cfg.DuplicateSession); policy {
+	case socket.DUPLICATE_REPLACE, socket.DUPLICATE_REJECT:
+		socket.DuplicateSession = policy
+	default:
+		log.Printf("Invalid duplicate session policy %s, using %s", policy, socket.DuplicateSession)
+	}
+
 	// Add game services to the map
-	gameServiceMap["ludo"] = &ludo.LudoGameService{}
+	gameServiceMap[ludo.GAME_NAME] = &ludo.LudoGameService{}
+	gameServiceMap[snakes.GAME_NAME] = &snakes.SnakesGameService{}
 
 	for _, v := range gameServiceMap {
 		// log.Printf("Game service %s added", k)
 		v.StartBoardManagement()
 	}
 
+	shutdownDeadline := time.Duration(cfg.ShutdownDeadlineSeconds) * time.Second
+	if shutdownDeadline <= 0 {
+		log.Printf("Invalid shutdown deadline %d, using %s", cfg.ShutdownDeadlineSeconds, DEFAULT_SHUTDOWN_DEADLINE)
+		shutdownDeadline = DEFAULT_SHUTDOWN_DEADLINE
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
 	var wg sync.WaitGroup
 	wg.Add(2)
 
@@ -67,12 +102,58 @@ func main() {
 	// Start REST API Server
 	go func() {
 		defer wg.Done()
-		if err := rest.StartRESTApiServer(*restServerPortPtr); err != nil {
+		if err := rest.StartRESTApiServer(*restServerPortPtr, gameServiceMap); err != nil {
 			log.Printf("REST API server error: %v", err)
 		}
 	}()
 
-	// Wait for both servers
+	// Run until the server is told to stop, then wait for both servers
+	<-ctx.Done()
+	stop() // A second signal kills the server without waiting for the drain
+
+	shutdown(shutdownDeadline)
 	wg.Wait()
 
+	log.Println("Server stopped")
+}
+
+// shutdown drains the server within the deadline. The new joins are refused and the WAITING boards closed, the
+// clients are told the server is going away and the state of the boards being played is stored. The connections
+// are closed once the messages queued for them are written, then both servers are stopped.
+func shutdown(deadline time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), deadline)
+	defer cancel()
+
+	closesAt, _ := ctx.Deadline()
+	log.Printf("Shutting down, the connections are closed by %s", closesAt.Format(time.RFC3339))
+
+	socket.Drain(closesAt)
+
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		for game, service := range gameServiceMap {
+			if err := service.Drain(); err != nil {
+				log.Printf("Error draining the %s boards: %v", game, err)
+			}
+		}
+	}()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		log.Printf("Shutdown deadline reached before the boards were drained")
+	}
+
+	if err := socket.CloseConnections(ctx); err != nil {
+		log.Printf("Error closing the connections: %v", err)
+	}
+
+	if err := socket.StopSocketServer(ctx); err != nil {
+		log.Printf("Error stopping the socket server: %v", err)
+	}
+
+	if err := rest.StopRESTApiServer(ctx); err != nil {
+		log.Printf("Error stopping the REST API server: %v", err)
+	}
 }
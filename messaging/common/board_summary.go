@@ -0,0 +1,19 @@
+package common
+
+// BoardSummary is what the lobby lists of a board, whatever game it is played
+type BoardSummary struct {
+	Game                       string                 `json:"game"`
+	BoardId                    string                 `json:"boardId"`
+	Players                    []PlayerSummary        `json:"players"`
+	PlayersRequiredToStartGame int                    `json:"playersRequiredToStartGame"`
+	Status                     string                 `json:"status"`
+	TicketAmount               int                    `json:"ticketAmount"`
+	Settings                   map[string]interface{} `json:"settings,omitempty"` // Settings of the game, e.g. the rule set of a Ludo board
+}
+
+// PlayerSummary is a player seated on a listed board
+type PlayerSummary struct {
+	PlayerId string `json:"playerId"`
+	Name     string `json:"name"`
+	IsBot    bool   `json:"isBot"`
+}
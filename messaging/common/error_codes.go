@@ -0,0 +1,122 @@
+package common
+
+import "errors"
+
+// ErrorCode is the key of an error of the catalog, the clients receive the code and the status of its details
+type ErrorCode string
+
+const (
+	INVALID_MESSAGE      ErrorCode = "INVALID_MESSAGE"
+	INVALID_TURN         ErrorCode = "INVALID_TURN"
+	INVALID_PAWN         ErrorCode = "INVALID_PAWN"
+	QUADRANT_TAKEN       ErrorCode = "QUADRANT_TAKEN"
+	INSUFFICIENT_BALANCE ErrorCode = "INSUFFICIENT_BALANCE"
+	BOARD_FULL           ErrorCode = "BOARD_FULL"
+	BOARD_CLOSED         ErrorCode = "BOARD_CLOSED"
+	ALREADY_CONNECTED    ErrorCode = "ALREADY_CONNECTED"
+	NOT_FOUND            ErrorCode = "NOT_FOUND"
+	SERVER_DRAINING      ErrorCode = "SERVER_DRAINING"
+	INTERNAL_ERROR       ErrorCode = "INTERNAL_ERROR"
+)
+
+type ErrorCodeDetails struct {
+	Code    string `json:"code"`
+	Status  int    `json:"status"` // Sent as the errorCode of the error message, as it was before the catalog
+	Message string `json:"message"`
+}
+
+var ErrorCodes = map[ErrorCode]ErrorCodeDetails{
+	INVALID_MESSAGE: {
+		Code:    "E100",
+		Status:  400,
+		Message: "Invalid message",
+	},
+	INVALID_TURN: {
+		Code:    "E101",
+		Status:  403,
+		Message: "It is not the turn of the player",
+	},
+	INVALID_PAWN: {
+		Code:    "E102",
+		Status:  422,
+		Message: "The pawn cannot make this move",
+	},
+	QUADRANT_TAKEN: {
+		Code:    "E103",
+		Status:  409,
+		Message: "The quadrant is not available",
+	},
+	INSUFFICIENT_BALANCE: {
+		Code:    "E200",
+		Status:  402,
+		Message: "Insufficient balance",
+	},
+	BOARD_FULL: {
+		Code:    "E300",
+		Status:  409,
+		Message: "Board is full",
+	},
+	BOARD_CLOSED: {
+		Code:    "E301",
+		Status:  409,
+		Message: "Board is not accepting players",
+	},
+	ALREADY_CONNECTED: {
+		Code:    "E302",
+		Status:  409,
+		Message: "Player is already connected to the board",
+	},
+	NOT_FOUND: {
+		Code:    "E303",
+		Status:  404,
+		Message: "Board or player not found",
+	},
+	SERVER_DRAINING: {
+		Code:    "E304",
+		Status:  503,
+		Message: "Server is shutting down, not accepting new connections",
+	},
+	INTERNAL_ERROR: {
+		Code:    "E500",
+		Status:  500,
+		Message: "Something went wrong please try again",
+	},
+}
+
+// GetErrorCodeDetails returns the details of an error code, unknown codes are internal errors
+func GetErrorCodeDetails(code ErrorCode) ErrorCodeDetails {
+	if details, exists := ErrorCodes[code]; exists {
+		return details
+	}
+	return ErrorCodes[INTERNAL_ERROR]
+}
+
+// SocketError is an error the game services return so the client receives its code from the catalog
+type SocketError struct {
+	code    ErrorCode
+	message string
+}
+
+func NewSocketError(code ErrorCode, message string) *SocketError {
+	return &SocketError{
+		code:    code,
+		message: message,
+	}
+}
+
+func (e *SocketError) Error() string {
+	return e.message
+}
+
+func (e *SocketError) GetCode() ErrorCode {
+	return e.code
+}
+
+// ToSocketError returns the SocketError wrapped in err, errors outside the catalog are internal errors
+func ToSocketError(err error) *SocketError {
+	var socketError *SocketError
+	if errors.As(err, &socketError) {
+		return socketError
+	}
+	return NewSocketError(INTERNAL_ERROR, err.Error())
+}
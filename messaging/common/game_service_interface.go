@@ -6,4 +6,6 @@ type GameService interface {
 	HandleDisconnection(boardId string, playerId string) error
 	CreateEmptyBoardInstances() error
 	StartBoardManagement()
+	ListBoards() []BoardSummary // Boards the lobby lists, those waiting for players or being played
+	Drain() error               // Closes the WAITING boards to new players and stores the boards being played, on shutdown
 }
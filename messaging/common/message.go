@@ -10,17 +10,26 @@ type Message interface {
 	ToObject(string) (Message, error)
 }
 
+// ERROR_EVENT is the event name of the error messages
+const ERROR_EVENT = "error"
+
 type SocketMessage struct {
 	Message
 	eventName    string
 	errorCode    int
 	errorMessage string
+	code         string // Code of the error in the catalog
+	requestId    string // Set by the client on a message, and echoed in the replies to it
 }
 
 func (sm SocketMessage) GetEventName() string {
 	return sm.eventName
 }
 
+func (sm SocketMessage) GetRequestId() string {
+	return sm.requestId
+}
+
 func NewSocketMessage(eventName string, errorCode int, errorMessage string) SocketMessage {
 	return SocketMessage{
 		eventName:    eventName,
@@ -29,12 +38,35 @@ func NewSocketMessage(eventName string, errorCode int, errorMessage string) Sock
 	}
 }
 
+// NewErrorMessage creates the error sent to a client, with the code of the error in the catalog and the request
+// id of the message that failed
+func NewErrorMessage(err error, requestId string) SocketMessage {
+	socketError := ToSocketError(err)
+	details := GetErrorCodeDetails(socketError.GetCode())
+
+	return SocketMessage{
+		eventName:    ERROR_EVENT,
+		errorCode:    details.Status,
+		errorMessage: socketError.Error(),
+		code:         details.Code,
+		requestId:    requestId,
+	}
+}
+
 func (sm SocketMessage) ToJSON() (string, error) {
-	resp, err := json.Marshal(map[string]interface{}{
+	data := map[string]interface{}{
 		"eventName":    sm.eventName,
 		"errorCode":    sm.errorCode,
 		"errorMessage": sm.errorMessage,
-	})
+	}
+	if sm.code != "" {
+		data["code"] = sm.code
+	}
+	if sm.requestId != "" {
+		data["requestId"] = sm.requestId
+	}
+
+	resp, err := json.Marshal(data)
 	if err != nil {
 		return "", err
 	}
@@ -74,5 +106,15 @@ func (sm SocketMessage) ToObject(msg string) (Message, error) {
 		}
 	}
 
-	return NewSocketMessage(eventNameStr, errorCode, errorMessage), nil
+	socketMessage := NewSocketMessage(eventNameStr, errorCode, errorMessage)
+
+	if code, ok := msgMap["code"].(string); ok {
+		socketMessage.code = code
+	}
+
+	if requestId, ok := msgMap["requestId"].(string); ok {
+		socketMessage.requestId = requestId
+	}
+
+	return socketMessage, nil
 }
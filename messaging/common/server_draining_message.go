@@ -0,0 +1,62 @@
+package common
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SERVER_DRAINING_EVENT is the event name of the message sent to every connection when the server starts shutting down
+const SERVER_DRAINING_EVENT = "Server.Draining"
+
+// ServerDrainingMessage tells a client the server is shutting down, and when its connection will be closed.
+// The state of the games being played is stored before the connections are closed.
+type ServerDrainingMessage struct {
+	Message
+	eventName string
+	message   string
+	closesAt  time.Time
+}
+
+// NewServerDrainingMessage creates a new ServerDrainingMessage.
+func NewServerDrainingMessage(message string, closesAt time.Time) *ServerDrainingMessage {
+	return &ServerDrainingMessage{
+		eventName: SERVER_DRAINING_EVENT,
+		message:   message,
+		closesAt:  closesAt,
+	}
+}
+
+// GetClosesAt returns when the connections are closed at the latest.
+func (m *ServerDrainingMessage) GetClosesAt() time.Time {
+	return m.closesAt
+}
+
+type serverDrainingJSON struct {
+	EventName string    `json:"eventName"`
+	Message   string    `json:"message"`
+	ClosesAt  time.Time `json:"closesAt"`
+}
+
+// ToJSON converts the ServerDrainingMessage to a JSON string.
+func (m *ServerDrainingMessage) ToJSON() (string, error) {
+	jsonData, err := json.Marshal(serverDrainingJSON{
+		EventName: m.eventName,
+		Message:   m.message,
+		ClosesAt:  m.closesAt,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+// ToObject converts a JSON string to a ServerDrainingMessage.
+func (m *ServerDrainingMessage) ToObject(data string) (Message, error) {
+	var intermediate serverDrainingJSON
+
+	if err := json.Unmarshal([]byte(data), &intermediate); err != nil {
+		return &ServerDrainingMessage{}, err
+	}
+
+	return NewServerDrainingMessage(intermediate.Message, intermediate.ClosesAt), nil
+}
@@ -1,14 +1,19 @@
 package rest
 
 import (
+	"context"
 	"fmt"
 	"lobby"
 	"log"
+	"messaging/common"
 	"net/http"
 )
 
-func StartRESTApiServer(port string) error {
-	lobbyRouteHandler := &lobby.LobbyRouteHandler{}
+// server is the HTTP server of the lobby, created up front so it can be shut down before it has started
+var server = &http.Server{}
+
+func StartRESTApiServer(port string, gsMap map[string]common.GameService) error {
+	lobbyRouteHandler := &lobby.LobbyRouteHandler{GameServices: gsMap}
 
 	lobbyRouteHandler.HandleRoutes()
 
@@ -17,5 +22,14 @@ func StartRESTApiServer(port string) error {
 	// })
 
 	log.Printf("Starting lobby server on port %s", port)
-	return http.ListenAndServe(fmt.Sprintf(":%s", port), nil)
+	server.Addr = fmt.Sprintf(":%s", port)
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// StopRESTApiServer stops the lobby server from accepting requests and waits for the open ones until ctx is done
+func StopRESTApiServer(ctx context.Context) error {
+	return server.Shutdown(ctx)
 }
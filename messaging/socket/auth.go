@@ -1,7 +1,10 @@
 package socket
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"net/http"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -22,6 +25,42 @@ func NewAuthClaim(playerId string, name string) AuthClaim {
 	}
 }
 
+// authenticate checks a request opening a connection, the same for every transport, and returns the context of
+// the connection with the wallet address of the player. When the request is refused, it returns the status to
+// answer with.
+func authenticate(r *http.Request) (*ConnectionContext, string, int, error) {
+	jwtToken := r.Header.Get("Authorization")
+
+	walletAddress := r.Header.Get("walletAddress")
+
+	// Get query param called boardId from url
+	boardId := r.URL.Query().Get("boardId")
+	// TODO: Get name from jwt token
+	game := r.URL.Query().Get("game")
+
+	// Spectators only watch the board, so they don't need a wallet
+	isSpectator := r.URL.Query().Get("spectate") == "true"
+
+	if jwtToken == "" || (walletAddress == "" && !isSpectator) {
+		return nil, "", http.StatusUnauthorized, errors.New("No jwt token or wallet address is provided")
+	}
+
+	playerId, name, err := VerifyToken(jwtToken)
+
+	if err != nil {
+		log.Printf("Error %s when verifying token", err)
+		return nil, "", http.StatusUnauthorized, errors.New("No token provided")
+	}
+
+	ctx, err := newConnectionContext(game, boardId, playerId, name, isSpectator)
+
+	if err != nil {
+		return nil, "", http.StatusBadRequest, err
+	}
+
+	return ctx, walletAddress, http.StatusOK, nil
+}
+
 func VerifyToken(tokenString string) (string, string, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		return secretKey, nil
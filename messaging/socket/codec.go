@@ -0,0 +1,130 @@
+package socket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec encodes the messages of a connection on the wire. The games build every message as JSON,
+// so a codec only converts JSON to its encoding and back and the games never see it.
+type Codec interface {
+	Name() string
+	FrameType() int                     // Websocket frame type the messages are written in
+	Encode(msg string) ([]byte, error)  // From the JSON message to the wire
+	Decode(data []byte) ([]byte, error) // From the wire to the JSON message
+}
+
+const (
+	CODEC_JSON    = "json"
+	CODEC_MSGPACK = "msgpack"
+)
+
+var codecs = map[string]Codec{
+	CODEC_JSON:    jsonCodec{},
+	CODEC_MSGPACK: msgpackCodec{},
+}
+
+// codecPreference is the order the codecs are picked in when a client offers several subprotocols
+var codecPreference = []string{CODEC_MSGPACK, CODEC_JSON}
+
+// negotiateCodec returns the codec of a new connection and the subprotocol to answer with.
+// The codec query parameter comes first, then the first subprotocol offered by the client the server
+// speaks, and JSON when the client asks for neither.
+func negotiateCodec(r *http.Request) (Codec, string, error) {
+	if name := r.URL.Query().Get("codec"); name != "" {
+		codec, exists := codecs[name]
+		if !exists {
+			return nil, "", fmt.Errorf("codec %s is not supported", name)
+		}
+		return codec, "", nil
+	}
+
+	offered := websocket.Subprotocols(r)
+	for _, name := range codecPreference {
+		for _, subprotocol := range offered {
+			if subprotocol == name {
+				return codecs[name], subprotocol, nil
+			}
+		}
+	}
+
+	return codecs[CODEC_JSON], "", nil
+}
+
+// jsonCodec writes the messages as they are built, in text frames
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string {
+	return CODEC_JSON
+}
+
+func (jsonCodec) FrameType() int {
+	return websocket.TextMessage
+}
+
+func (jsonCodec) Encode(msg string) ([]byte, error) {
+	return []byte(msg), nil
+}
+
+func (jsonCodec) Decode(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+// msgpackCodec writes the messages as MessagePack in binary frames, with the fields of the JSON message
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string {
+	return CODEC_MSGPACK
+}
+
+func (msgpackCodec) FrameType() int {
+	return websocket.BinaryMessage
+}
+
+func (msgpackCodec) Encode(msg string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(msg)))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("message is not JSON: %v", err)
+	}
+
+	return msgpack.Marshal(compactNumbers(value))
+}
+
+func (msgpackCodec) Decode(data []byte) ([]byte, error) {
+	var value interface{}
+	if err := msgpack.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("message is not MessagePack: %v", err)
+	}
+
+	return json.Marshal(value)
+}
+
+// compactNumbers turns the JSON numbers of a decoded message into integers where they are whole,
+// so they take a byte or a few on the wire instead of a float64
+func compactNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, field := range v {
+			v[key] = compactNumbers(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = compactNumbers(item)
+		}
+	}
+	return value
+}
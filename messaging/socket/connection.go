@@ -1,19 +1,290 @@
 package socket
 
 import (
+	"encoding/json"
+	"log"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 )
 
+// SlowConsumerPolicy decides what happens to a message sent to a connection whose queue is full
+type SlowConsumerPolicy string
+
+const (
+	SLOW_CONSUMER_DROP       SlowConsumerPolicy = "DROP"       // The message is dropped
+	SLOW_CONSUMER_COALESCE   SlowConsumerPolicy = "COALESCE"   // The oldest queued message of the same event makes room for it, else it is dropped
+	SLOW_CONSUMER_DISCONNECT SlowConsumerPolicy = "DISCONNECT" // The connection is closed, the client gets the whole board back when it reconnects
+)
+
+// SlowConsumer is the policy applied to the connections whose queue is full
+var SlowConsumer = SLOW_CONSUMER_DISCONNECT
+
+// SendQueueSize is the number of messages waiting to be written a connection holds before it is a slow consumer
+var SendQueueSize = 256
+
+// wire is what the write pump writes the messages of a connection to, a websocket or an event stream
+type wire interface {
+	WriteMessage(messageType int, data []byte) error
+	SetWriteDeadline(t time.Time) error
+	Close() error
+}
+
 type Connection struct {
-	Conn      *websocket.Conn
-	timestamp time.Time
+	Conn          *websocket.Conn // Nil for the connections of the other transports
+	wire          wire
+	timestamp     time.Time
+	receiver      func(string) // Set for in-process players (e.g. bots) that have no websocket
+	remoteAddress string       // IP the client connected from
+	context       *ConnectionContext
+	codec         Codec // Encoding of the messages on the wire
+
+	send      chan string   // Messages waiting for the write pump
+	enqueue   sync.Mutex    // Serializes the senders, so coalescing keeps the order of the queue
+	done      chan struct{} // Closed to stop the write pump
+	stopped   chan struct{} // Closed once the write pump has returned and the wire is closed
+	closeCode int           // Code of the close frame, set once when the connection is closed
+	closeOnce sync.Once
+}
+
+// NewConnection wraps the websocket and starts its write pump, the only goroutine writing to it
+func NewConnection(conn *websocket.Conn, remoteAddress string, context *ConnectionContext, codec Codec) *Connection {
+	c := newConnection(conn, remoteAddress, context, codec)
+	c.Conn = conn
+	return c
 }
 
-func NewConnection(conn *websocket.Conn) *Connection {
+// newConnection starts the write pump of a connection writing to the wire
+func newConnection(w wire, remoteAddress string, context *ConnectionContext, codec Codec) *Connection {
+	c := &Connection{
+		wire:          w,
+		timestamp:     time.Now(),
+		remoteAddress: remoteAddress,
+		context:       context,
+		codec:         codec,
+		send:          make(chan string, SendQueueSize),
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+
+	metrics.opened()
+	go c.writePump()
+
+	return c
+}
+
+// NewVirtualConnection creates a connection for a player that lives inside the server.
+// Every message written to it is handed to the receiver instead of a websocket.
+func NewVirtualConnection(receiver func(string)) *Connection {
 	return &Connection{
-		Conn:      conn,
 		timestamp: time.Now(),
+		receiver:  receiver,
+	}
+}
+
+// Context returns what the connection was opened for, nil for in-process players
+func (c *Connection) Context() *ConnectionContext {
+	return c.context
+}
+
+// Codec returns the encoding of the messages of the connection, nil for in-process players
+func (c *Connection) Codec() Codec {
+	return c.codec
+}
+
+// Transport returns how the client is connected, websocket or sse, empty for in-process players
+func (c *Connection) Transport() string {
+	switch {
+	case c.receiver != nil:
+		return ""
+	case c.Conn != nil:
+		return TRANSPORT_WEBSOCKET
+	default:
+		return TRANSPORT_SSE
+	}
+}
+
+// IsVirtual reports whether the connection belongs to an in-process player
+func (c *Connection) IsVirtual() bool {
+	return c.receiver != nil
+}
+
+// write queues the message for the write pump without waiting for the client.
+// Returns true when the message was not queued.
+func (c *Connection) write(msg string) bool {
+	if c.receiver != nil {
+		c.receiver(msg)
+		return false
+	}
+
+	c.enqueue.Lock()
+	defer c.enqueue.Unlock()
+
+	select {
+	case <-c.done:
+		return true
+	default:
+	}
+
+	select {
+	case c.send <- msg:
+		metrics.queued(len(c.send))
+		return false
+	default:
+	}
+
+	switch SlowConsumer {
+	case SLOW_CONSUMER_COALESCE:
+		if c.coalesce(msg) {
+			metrics.coalesced()
+			return false
+		}
+		metrics.dropped()
+	case SLOW_CONSUMER_DROP:
+		metrics.dropped()
+	default:
+		log.Printf("Closing slow connection from %s with %d queued messages", c.remoteAddress, len(c.send))
+		metrics.disconnected()
+		c.Close()
+	}
+	return true
+}
+
+// coalesce removes the oldest queued message of the same event as msg and queues msg at the end.
+// It must be called with the enqueue lock held.
+func (c *Connection) coalesce(msg string) bool {
+	event := eventName(msg)
+
+	var queued []string
+	for len(c.send) > 0 {
+		select {
+		case m := <-c.send:
+			queued = append(queued, m)
+		default:
+		}
+	}
+
+	replaced := false
+	for _, m := range queued {
+		if !replaced && eventName(m) == event {
+			replaced = true
+			continue
+		}
+		c.send <- m
+	}
+
+	if replaced {
+		c.send <- msg
+	}
+	return replaced
+}
+
+// eventName returns the event of a JSON message, empty if it has none
+func eventName(msg string) string {
+	var message struct {
+		EventName string `json:"eventName"`
+	}
+	json.Unmarshal([]byte(msg), &message)
+	return message.EventName
+}
+
+// Close stops the write pump once the queued messages are written and closes the wire
+func (c *Connection) Close() {
+	c.closeWith(websocket.CloseNormalClosure)
+}
+
+// closeWith closes the connection like Close, with the code of the close frame. The first close wins.
+func (c *Connection) closeWith(code int) {
+	if c.receiver != nil {
+		return
+	}
+	c.closeOnce.Do(func() {
+		c.closeCode = code
+		close(c.done)
+	})
+}
+
+// isClosed reports whether the connection was closed, in-process connections never are
+func (c *Connection) isClosed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// writePump writes the queued messages and the pings to the wire, each within writeTimeout.
+// A failed write closes the wire, which ends the read loop of the connection.
+func (c *Connection) writePump() {
+	ticker := time.NewTicker(pingInterval)
+
+	defer func() {
+		ticker.Stop()
+		c.wire.Close()
+
+		// Senders see the connection closed once they get the lock, so nothing is queued after this
+		c.enqueue.Lock()
+		metrics.closed(len(c.send))
+		c.enqueue.Unlock()
+
+		close(c.stopped)
+	}()
+
+	for {
+		select {
+		case msg := <-c.send:
+			metrics.written()
+			if !c.writeEncoded(msg) {
+				c.Close()
+				return
+			}
+		case <-ticker.C:
+			if !c.writeMessage(websocket.PingMessage, nil) {
+				log.Printf("Ping failed for connection from %s", c.remoteAddress)
+				c.Close()
+				return
+			}
+		case <-c.done:
+			c.flush()
+			return
+		}
+	}
+}
+
+// flush writes what is left in the queue and the close frame, before the websocket is closed
+func (c *Connection) flush() {
+	for {
+		select {
+		case msg := <-c.send:
+			metrics.written()
+			if !c.writeEncoded(msg) {
+				return
+			}
+		default:
+			c.writeMessage(websocket.CloseMessage, websocket.FormatCloseMessage(c.closeCode, ""))
+			return
+		}
+	}
+}
+
+// writeEncoded writes a queued message in the codec of the connection. A message the codec cannot encode is
+// dropped, the connection stays open.
+func (c *Connection) writeEncoded(msg string) bool {
+	data, err := c.codec.Encode(msg)
+	if err != nil {
+		log.Printf("Error %s when encoding message in %s for connection from %s", err, c.codec.Name(), c.remoteAddress)
+		return true
+	}
+	return c.writeMessage(c.codec.FrameType(), data)
+}
+
+func (c *Connection) writeMessage(messageType int, data []byte) bool {
+	c.wire.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err := c.wire.WriteMessage(messageType, data); err != nil {
+		log.Printf("Error %s when sending message to client", err)
+		return false
 	}
+	return true
 }
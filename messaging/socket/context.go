@@ -0,0 +1,42 @@
+package socket
+
+import (
+	"fmt"
+	"messaging/common"
+)
+
+// DEFAULT_GAME is the game of the connections that do not name one
+const DEFAULT_GAME = "ludo"
+
+// ConnectionContext is what a connection was opened for. It is kept on the connection and goes through its
+// read loop, so messages and disconnections reach the game service and board the connection was opened with.
+type ConnectionContext struct {
+	Game      string             // Name of the game service in the gameServiceMap
+	Service   common.GameService // Service the messages and the disconnection of the connection go to
+	BoardId   string
+	PlayerId  string
+	Name      string
+	Spectator bool // Spectators only receive the board messages
+}
+
+// newConnectionContext returns the context of a connection to the board of the named game, an error if
+// no service plays the game
+func newConnectionContext(game string, boardId string, playerId string, name string, spectator bool) (*ConnectionContext, error) {
+	if game == "" {
+		game = DEFAULT_GAME
+	}
+
+	service := gameServiceMap[game]
+	if service == nil {
+		return nil, fmt.Errorf("game %s is not hosted on this server", game)
+	}
+
+	return &ConnectionContext{
+		Game:      game,
+		Service:   service,
+		BoardId:   boardId,
+		PlayerId:  playerId,
+		Name:      name,
+		Spectator: spectator,
+	}, nil
+}
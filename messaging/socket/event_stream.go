@@ -0,0 +1,178 @@
+package socket
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	TRANSPORT_WEBSOCKET = "websocket"
+	TRANSPORT_SSE       = "sse"
+)
+
+// MaxCommandSize is the largest message a client can POST to a board
+const MaxCommandSize = 64 * 1024
+
+// eventStream is the wire of the clients that cannot open a websocket. The server writes the messages as
+// Server-Sent Events on the response of a long-lived GET, and the client sends its messages with POST requests.
+type eventStream struct {
+	w         http.ResponseWriter
+	flusher   http.Flusher
+	rc        *http.ResponseController
+	closed    chan struct{} // Closed once the write pump is done with the stream, the GET can return then
+	closeOnce sync.Once
+}
+
+func newEventStream(w http.ResponseWriter, flusher http.Flusher) *eventStream {
+	return &eventStream{
+		w:       w,
+		flusher: flusher,
+		rc:      http.NewResponseController(w),
+		closed:  make(chan struct{}),
+	}
+}
+
+// WriteMessage writes the message as an event, pings as comments the clients ignore, and the close frame as a
+// close event telling the client not to reconnect on its own
+func (s *eventStream) WriteMessage(messageType int, data []byte) error {
+	var err error
+
+	switch messageType {
+	case websocket.PingMessage:
+		_, err = io.WriteString(s.w, ": ping\n\n")
+	case websocket.CloseMessage:
+		_, err = io.WriteString(s.w, "event: close\ndata: \n\n")
+	default:
+		_, err = io.WriteString(s.w, "data: "+strings.ReplaceAll(string(data), "\n", "\ndata: ")+"\n\n")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	s.flusher.Flush()
+	return nil
+}
+
+func (s *eventStream) SetWriteDeadline(t time.Time) error {
+	return s.rc.SetWriteDeadline(t)
+}
+
+func (s *eventStream) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+	return nil
+}
+
+type eventStreamHandler struct{}
+
+// ServeHTTP opens the event stream of a player or a spectator to a board. The request is authenticated like
+// the websocket one, and the connection goes in the same registry, so SendMessage and BroadcastMessage reach it
+// the same way. Messages are always JSON.
+func (esh eventStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if refuseWhileDraining(w) {
+		return
+	}
+
+	ctx, walletAddress, status, err := authenticate(r)
+
+	if err != nil {
+		w.WriteHeader(status)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("streaming is not supported"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Proxies must not buffer the events
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	stream := newEventStream(w, flusher)
+	connection := newConnection(stream, clientIP(r), ctx, codecs[CODEC_JSON])
+
+	if ctx.Spectator {
+		sessions.addSpectator(ctx.BoardId, connection)
+		log.Printf("[@eventStreamHandler] Spectator %s is watching board %s", ctx.PlayerId, ctx.BoardId)
+
+		waitForStream(r, stream)
+		sessions.removeSpectator(ctx.BoardId, connection)
+		connection.Close()
+		<-stream.closed
+		return
+	}
+
+	if !join(connection, ctx, walletAddress) {
+		<-stream.closed
+		return
+	}
+
+	waitForStream(r, stream)
+	handleDisconnection(connection)
+
+	// The response cannot be written once the handler returns, so it waits for the write pump to stop
+	<-stream.closed
+}
+
+// waitForStream blocks until the client goes away or the server closes the connection
+func waitForStream(r *http.Request, stream *eventStream) {
+	select {
+	case <-r.Context().Done():
+	case <-stream.closed:
+	}
+}
+
+// handleStreamCommand reads a message a client of an event stream POSTs to its board. The message is handled like
+// a websocket one, and its errors are sent on the stream with its request id. The request is authenticated like
+// the stream, and is accepted once the message is handed to the game service.
+func handleStreamCommand(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	playerId, _, err := VerifyToken(r.Header.Get("Authorization"))
+
+	if err != nil {
+		log.Printf("Error %s when verifying token", err)
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("No token provided"))
+		return
+	}
+
+	boardId := r.URL.Query().Get("boardId")
+	connection := sessions.lookup(playerId, boardId)
+
+	if connection == nil || connection.Transport() != TRANSPORT_SSE {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf("player %s has no event stream open to board %s", playerId, boardId)))
+		return
+	}
+
+	msg, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxCommandSize))
+
+	if err != nil {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	handleMessage(connection, connection.Context(), msg)
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,75 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+)
+
+// QueueMetrics describes the outbound queues of the websocket connections
+type QueueMetrics struct {
+	Connections    int64 `json:"connections"`    // Open websocket connections
+	QueuedMessages int64 `json:"queuedMessages"` // Messages waiting in all the queues
+	MaxQueueDepth  int64 `json:"maxQueueDepth"`  // Deepest queue seen since the server started
+	Written        int64 `json:"written"`
+	Dropped        int64 `json:"dropped"`
+	Coalesced      int64 `json:"coalesced"`
+	Disconnected   int64 `json:"disconnected"` // Connections closed for being slow consumers
+}
+
+type queueCounters struct {
+	connections    atomic.Int64
+	queuedMessages atomic.Int64
+	maxQueueDepth  atomic.Int64
+	writtenCount   atomic.Int64
+	droppedCount   atomic.Int64
+	coalescedCount atomic.Int64
+	disconnects    atomic.Int64
+}
+
+var metrics queueCounters
+
+func (m *queueCounters) opened() { m.connections.Add(1) }
+
+// closed forgets the connection and the messages left in its queue
+func (m *queueCounters) closed(abandoned int) {
+	m.connections.Add(-1)
+	m.queuedMessages.Add(-int64(abandoned))
+}
+
+func (m *queueCounters) queued(depth int) {
+	m.queuedMessages.Add(1)
+	for {
+		max := m.maxQueueDepth.Load()
+		if int64(depth) <= max || m.maxQueueDepth.CompareAndSwap(max, int64(depth)) {
+			return
+		}
+	}
+}
+
+func (m *queueCounters) written() {
+	m.queuedMessages.Add(-1)
+	m.writtenCount.Add(1)
+}
+
+func (m *queueCounters) dropped()      { m.droppedCount.Add(1) }
+func (m *queueCounters) coalesced()    { m.coalescedCount.Add(1) }
+func (m *queueCounters) disconnected() { m.disconnects.Add(1) }
+
+// GetQueueMetrics returns the current state of the outbound queues
+func GetQueueMetrics() QueueMetrics {
+	return QueueMetrics{
+		Connections:    metrics.connections.Load(),
+		QueuedMessages: metrics.queuedMessages.Load(),
+		MaxQueueDepth:  metrics.maxQueueDepth.Load(),
+		Written:        metrics.writtenCount.Load(),
+		Dropped:        metrics.droppedCount.Load(),
+		Coalesced:      metrics.coalescedCount.Load(),
+		Disconnected:   metrics.disconnects.Load(),
+	}
+}
+
+func handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(GetQueueMetrics())
+}
@@ -0,0 +1,172 @@
+package socket
+
+import (
+	"fmt"
+	"log"
+	"messaging/common"
+	"sync"
+)
+
+// DuplicateSessionPolicy decides what happens when a player opens a board it is already connected to
+type DuplicateSessionPolicy string
+
+const (
+	DUPLICATE_REPLACE DuplicateSessionPolicy = "REPLACE" // The newest connection wins, the old one gets Session.Replaced and is closed
+	DUPLICATE_REJECT  DuplicateSessionPolicy = "REJECT"  // The new connection is refused while the old one is open
+)
+
+// DuplicateSession is the policy applied to a second connection of a player to the same board
+var DuplicateSession = DUPLICATE_REPLACE
+
+// SESSION_REPLACED is sent to a connection before it is closed for a newer one to the same board
+const SESSION_REPLACED = "Session.Replaced"
+
+// registry holds the open connections by board and player, a player has one connection per board it plays on
+type registry struct {
+	mu         sync.RWMutex
+	boards     map[string]map[string]*Connection // Connection of every player by board
+	spectators map[string][]*Connection          // Spectator connections by board
+}
+
+var sessions = &registry{
+	boards:     map[string]map[string]*Connection{},
+	spectators: map[string][]*Connection{},
+}
+
+// register adds the connection of the player to the board, applying the duplicate session policy
+// Returns:
+//   - *Connection: The connection it replaced, nil if there was none
+//   - error: Error if the policy rejects the connection
+func (r *registry) register(playerId string, boardId string, c *Connection) (*Connection, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.boards[boardId] == nil {
+		r.boards[boardId] = map[string]*Connection{}
+	}
+
+	previous := r.boards[boardId][playerId]
+	if previous != nil && DuplicateSession == DUPLICATE_REJECT {
+		return nil, fmt.Errorf("player %s is already connected to board %s", playerId, boardId)
+	}
+
+	r.boards[boardId][playerId] = c
+	return previous, nil
+}
+
+// unregister removes the connection of the player from the board.
+// Returns true if it was the current connection of the pair, false if a newer one had replaced it.
+func (r *registry) unregister(playerId string, boardId string, c *Connection) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.boards[boardId][playerId] != c {
+		return false
+	}
+
+	delete(r.boards[boardId], playerId)
+	if len(r.boards[boardId]) == 0 {
+		delete(r.boards, boardId)
+	}
+	return true
+}
+
+// restore gives the board back to the connection c replaced when c is refused, or removes c when it replaced none.
+// A previous connection closed in the meantime is not put back. Nothing changes if a newer connection replaced c.
+func (r *registry) restore(playerId string, boardId string, c *Connection, previous *Connection) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.boards[boardId][playerId] != c {
+		return
+	}
+
+	if previous != nil && !previous.isClosed() {
+		r.boards[boardId][playerId] = previous
+		return
+	}
+
+	delete(r.boards[boardId], playerId)
+	if len(r.boards[boardId]) == 0 {
+		delete(r.boards, boardId)
+	}
+}
+
+// lookup returns the connection of the player to the board, nil if it is not connected
+func (r *registry) lookup(playerId string, boardId string) *Connection {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.boards[boardId][playerId]
+}
+
+// players returns the connections of the players of the board, copied so they can be written without the lock
+func (r *registry) players(boardId string) map[string]*Connection {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	connections := make(map[string]*Connection, len(r.boards[boardId]))
+	for playerId, c := range r.boards[boardId] {
+		connections[playerId] = c
+	}
+	return connections
+}
+
+func (r *registry) addSpectator(boardId string, c *Connection) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.spectators[boardId] = append(r.spectators[boardId], c)
+}
+
+func (r *registry) removeSpectator(boardId string, c *Connection) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	spectators := make([]*Connection, 0)
+	for _, spectator := range r.spectators[boardId] {
+		if spectator != c {
+			spectators = append(spectators, spectator)
+		}
+	}
+
+	if len(spectators) == 0 {
+		delete(r.spectators, boardId)
+		return
+	}
+	r.spectators[boardId] = spectators
+}
+
+// boardSpectators returns the spectator connections of the board, copied so they can be written without the lock
+func (r *registry) boardSpectators(boardId string) []*Connection {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]*Connection(nil), r.spectators[boardId]...)
+}
+
+// all returns the connections of every player and spectator of every board, copied so they can be written without the lock
+func (r *registry) all() []*Connection {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	connections := []*Connection{}
+	for _, players := range r.boards {
+		for _, c := range players {
+			connections = append(connections, c)
+		}
+	}
+	for _, spectators := range r.spectators {
+		connections = append(connections, spectators...)
+	}
+	return connections
+}
+
+// replace tells the replaced connection why it is closed, then closes it once the notice is written
+func replace(previous *Connection, boardId string, playerId string) {
+	log.Printf("[@ServeHTTP] Player %s opened board %s again, closing the previous connection", playerId, boardId)
+
+	notice, _ := common.NewSocketMessage(SESSION_REPLACED, 409, fmt.Sprintf("board %s was opened on another connection", boardId)).ToJSON()
+	previous.write(notice)
+	previous.Close()
+}
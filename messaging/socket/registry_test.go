@@ -0,0 +1,70 @@
+package socket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// discardWire drops everything written to it
+type discardWire struct{}
+
+func (discardWire) WriteMessage(messageType int, data []byte) error { return nil }
+func (discardWire) SetWriteDeadline(t time.Time) error              { return nil }
+func (discardWire) Close() error                                    { return nil }
+
+func newTestRegistry() *registry {
+	return &registry{
+		boards:     map[string]map[string]*Connection{},
+		spectators: map[string][]*Connection{},
+	}
+}
+
+func TestRefusedJoinUnderReplace(t *testing.T) {
+	r := newTestRegistry()
+	healthy := NewVirtualConnection(func(string) {})
+	refused := NewVirtualConnection(func(string) {})
+
+	_, err := r.register("alice", "board", healthy)
+	assert.NoError(t, err)
+	previous, err := r.register("alice", "board", refused)
+	assert.NoError(t, err)
+	assert.Same(t, healthy, previous)
+
+	r.restore("alice", "board", refused, previous)
+	assert.Same(t, healthy, r.lookup("alice", "board"), "a refused join should give the board back to the previous connection")
+
+	closed := newConnection(discardWire{}, "", nil, nil)
+	previous, _ = r.register("alice", "board", closed)
+	assert.Same(t, healthy, previous)
+	r.restore("alice", "board", closed, nil)
+	assert.Nil(t, r.lookup("alice", "board"), "a refused first join should leave nothing behind")
+
+	dead := newConnection(discardWire{}, "", nil, nil)
+	r.register("alice", "board", dead)
+	previous, _ = r.register("alice", "board", refused)
+	dead.Close()
+	r.restore("alice", "board", refused, previous)
+	assert.Nil(t, r.lookup("alice", "board"), "a previous connection closed meanwhile should not be put back")
+	closed.Close()
+}
+
+func TestRefusedJoinUnderReject(t *testing.T) {
+	DuplicateSession = DUPLICATE_REJECT
+	defer func() { DuplicateSession = DUPLICATE_REPLACE }()
+
+	r := newTestRegistry()
+	refused := NewVirtualConnection(func(string) {})
+	retry := NewVirtualConnection(func(string) {})
+
+	previous, err := r.register("alice", "board", refused)
+	assert.NoError(t, err)
+	r.restore("alice", "board", refused, previous)
+
+	_, err = r.register("alice", "board", retry)
+	assert.NoError(t, err, "a refused join should not block the next one")
+
+	_, err = r.register("alice", "board", NewVirtualConnection(func(string) {}))
+	assert.Error(t, err, "a second connection should be rejected while the first is open")
+}
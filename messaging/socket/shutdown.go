@@ -0,0 +1,88 @@
+package socket
+
+import (
+	"context"
+	"log"
+	"messaging/common"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// draining is set once the server starts shutting down, the new connections are refused from then on
+var draining atomic.Bool
+
+// server is the HTTP server of the socket, created up front so it can be shut down before it has started
+var server = &http.Server{}
+
+// IsDraining reports whether the server is shutting down
+func IsDraining() bool {
+	return draining.Load()
+}
+
+// Drain refuses the new connections and tells every open one that the server is shutting down, and that it is
+// closed by closesAt at the latest. The open connections keep working until CloseConnections.
+func Drain(closesAt time.Time) {
+	if !draining.CompareAndSwap(false, true) {
+		return
+	}
+
+	notice, err := common.NewServerDrainingMessage("Server is shutting down", closesAt).ToJSON()
+
+	if err != nil {
+		log.Printf("Error %s when marshalling message", err)
+		return
+	}
+
+	connections := sessions.all()
+	for _, connection := range connections {
+		connection.write(notice)
+	}
+
+	log.Printf("[@Drain] Server is draining, %d connections notified", len(connections))
+}
+
+// CloseConnections closes every connection with a going away close frame, once the messages queued before it are
+// written. The players are not disconnected from their games. It waits for the connections until ctx is done.
+func CloseConnections(ctx context.Context) error {
+	connections := sessions.all()
+
+	for _, connection := range connections {
+		connection.closeWith(websocket.CloseGoingAway)
+	}
+
+	for _, connection := range connections {
+		if connection.IsVirtual() {
+			continue
+		}
+
+		select {
+		case <-connection.stopped:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	log.Printf("[@CloseConnections] Closed %d connections", len(connections))
+	return nil
+}
+
+// StopSocketServer stops the socket server from accepting requests and waits for the open ones until ctx is done.
+// The websockets are not waited for, they are closed by CloseConnections.
+func StopSocketServer(ctx context.Context) error {
+	return server.Shutdown(ctx)
+}
+
+// refuseWhileDraining answers the request with the draining error while the server shuts down.
+// Returns true when the request was refused.
+func refuseWhileDraining(w http.ResponseWriter) bool {
+	if !IsDraining() {
+		return false
+	}
+
+	w.WriteHeader(common.GetErrorCodeDetails(common.SERVER_DRAINING).Status)
+	w.Write([]byte(common.GetErrorCodeDetails(common.SERVER_DRAINING).Message))
+	return true
+}
@@ -1,11 +1,14 @@
 package socket
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"messaging/common"
+	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -14,65 +17,43 @@ import (
 const (
 	readWait     = 15 * time.Second // Max time to wait for client response
 	pingInterval = 5 * time.Second  // Frequency of server pings
-	writeTimeout = 5 * time.Second  // Time to wait for ping/pong writes
+	writeTimeout = 5 * time.Second  // Time to wait for every write, messages and pings
 )
 
-var playerConnections = make(map[string][]*Connection)
 var gameServiceMap = make(map[string]common.GameService)
-var boardPlayerMap = make(map[string][]string)
-
-var gameService common.GameService
 
 type webSocketHandler struct {
 	upgrader websocket.Upgrader
 }
 
-func writeResponse(msg string, c *websocket.Conn) bool {
-	var err = c.WriteMessage(websocket.TextMessage, []byte(msg))
-	if err != nil {
-		log.Printf("Error %s when sending message to client", err)
-		return true
-	}
-	return false
-}
-
 func (wsh webSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
-	jwtToken := r.Header.Get("Authorization")
-
-	walletAddress := r.Header.Get("walletAddress")
-
-	// Get query param called boardId from url
-	boardId := r.URL.Query().Get("boardId")
-	// TODO: Get name from jwt token
-	game := r.URL.Query().Get("game")
-
-	var gameService = gameServiceMap[game]
-
-	if gameService == nil {
-		// Hack: Default to ludo
-		gameService = gameServiceMap["ludo"]
+	if refuseWhileDraining(w) {
+		return
 	}
 
-	if jwtToken == "" || walletAddress == "" {
-		// log.Println("No token provided")
-		w.WriteHeader(http.StatusUnauthorized)
-		w.Write([]byte("No jwt token or wallet address is provided"))
+	ctx, walletAddress, status, err := authenticate(r)
+
+	if err != nil {
+		w.WriteHeader(status)
+		w.Write([]byte(err.Error()))
 		return
 	}
 
-	// log.Printf("Token: %s", jwtToken)
-
-	playerId, name, err := VerifyToken(jwtToken)
+	codec, subprotocol, err := negotiateCodec(r)
 
 	if err != nil {
-		log.Printf("Error %s when verifying token", err)
-		w.WriteHeader(http.StatusUnauthorized)
-		w.Write([]byte("No token provided"))
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
 		return
 	}
 
-	c, err := wsh.upgrader.Upgrade(w, r, nil)
+	var responseHeader http.Header
+	if subprotocol != "" {
+		responseHeader = http.Header{"Sec-Websocket-Protocol": {subprotocol}}
+	}
+
+	c, err := wsh.upgrader.Upgrade(w, r, responseHeader)
 
 	if err != nil {
 		// log.Printf("error %s when upgrading connection to websocket", err)
@@ -80,34 +61,13 @@ func (wsh webSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	connection := NewConnection(c)
-
-	playerConnections[playerId] = append(playerConnections[playerId], connection)
+	connection := NewConnection(c, clientIP(r), ctx, codec)
 
-	// Check if player is already in the board's player list
-	playerExists := false
-
-	for _, pid := range boardPlayerMap[boardId] {
-		if pid == playerId {
-			playerExists = true
-			log.Printf("[@ServeHTTP] Player %s already exists in board %s", playerId, boardId)
-			break
-		}
-	}
-
-	if !playerExists {
-		boardPlayerMap[boardId] = append(boardPlayerMap[boardId], playerId)
-		log.Printf("[@ServeHTTP] Added player %s to board %s", playerId, boardId)
+	if ctx.Spectator {
+		serveSpectator(connection, ctx)
+		return
 	}
 
-	log.Printf("[@ServeHTTP] New connection established - Player: %s, Board: %s, Game: %s", playerId, boardId, game)
-	// log.Printf("[@ServeHTTP] Active players in board %s: %v", boardId, boardPlayerMap[boardId])
-	// numConnections := len(playerConnections)
-	// log.Printf("[@ServeHTTP] Total active connections: %d", numConnections)
-
-	// TODO: Get name from the platform
-	addPlayerError := gameService.AddPlayer(boardId, playerId, name, walletAddress)
-
 	// Configure ping/pong handlers properly
 	c.SetPongHandler(func(appData string) error {
 		c.SetReadDeadline(time.Now().Add(readWait)) // Use configured readWait
@@ -117,36 +77,58 @@ func (wsh webSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Set initial read deadline immediately after upgrade
 	c.SetReadDeadline(time.Now().Add(readWait))
 
-	go func() {
-		ticker := time.NewTicker(pingInterval)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ticker.C:
-				c.SetWriteDeadline(time.Now().Add(writeTimeout))
-				if err := c.WriteMessage(websocket.PingMessage, nil); err != nil {
-					log.Printf("Ping failed for %s: %v", playerId, err)
-					c.Close() // This will trigger the read error
-					return
-				}
-			}
-		}
+	if !join(connection, ctx, walletAddress) {
+		return
+	}
+
+	defer func() {
+		handleDisconnection(connection)
 	}()
 
+	readLoop(connection, ctx)
+}
+
+// join registers the connection of the player on its board and seats the player, the same for every transport.
+// Returns false when the player is refused, the connection is closed then.
+func join(connection *Connection, ctx *ConnectionContext, walletAddress string) bool {
+	boardId, playerId := ctx.BoardId, ctx.PlayerId
+
+	previous, err := sessions.register(playerId, boardId, connection)
+
+	if err != nil {
+		log.Printf("[@join] %s", err)
+		SendErrorMessage(common.NewSocketError(common.ALREADY_CONNECTED, err.Error()), "", connection)
+		connection.Close()
+		return false
+	}
+
+	log.Printf("[@join] New connection established - Player: %s, Board: %s, Game: %s, Transport: %s, Codec: %s", playerId, boardId, ctx.Game, connection.Transport(), connection.codec.Name())
+	// log.Printf("[@ServeHTTP] Active players in board %s: %v", boardId, boardPlayerMap[boardId])
+	// numConnections := len(playerConnections)
+	// log.Printf("[@ServeHTTP] Total active connections: %d", numConnections)
+
+	// TODO: Get name from the platform
+	addPlayerError := ctx.Service.AddPlayer(boardId, playerId, ctx.Name, walletAddress)
+
 	if addPlayerError != nil {
 		log.Printf("Error %s when adding player to game", addPlayerError)
-		SendErrorMessage(addPlayerError.Error(), c)
-		w.WriteHeader(http.StatusInternalServerError)
-		// delete(playerConnections, playerId)
-		// delete(boardPlayerMap, boardId)
-		c.Close()
-		return
+		SendErrorMessage(addPlayerError, "", connection)
+		// The previous connection of the player keeps the board, it is only replaced once the player is seated
+		sessions.restore(playerId, boardId, connection, previous)
+		connection.Close()
+		return false
 	}
 
-	defer func() {
-		handleDisconnection(c, boardId, playerId)
-	}()
+	if previous != nil {
+		replace(previous, boardId, playerId)
+	}
+
+	return true
+}
+
+// readLoop hands the messages of the connection to the game service of its context until the connection fails
+func readLoop(connection *Connection, ctx *ConnectionContext) {
+	c := connection.Conn
 
 	for {
 		mt, msg, err := c.ReadMessage()
@@ -156,36 +138,74 @@ func (wsh webSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			}
 
 			// log.Printf("Error %s when reading message from client", err)
-			SendErrorMessage("Error %s when reading message from client", c)
+			SendErrorMessage(fmt.Errorf("error %s when reading message from client", err), "", connection)
 			return
 		}
 
 		c.SetReadDeadline(time.Now().Add(readWait))
 
+		// Binary frames are only read from the connections that negotiated a binary codec, text frames are always JSON
 		if mt == websocket.BinaryMessage {
-			SendErrorMessage("socket doesn't support binary messages", c)
-			err = c.WriteMessage(websocket.TextMessage, []byte("socket doesn't support binary messages"))
+			if connection.codec.FrameType() != websocket.BinaryMessage {
+				SendErrorMessage(common.NewSocketError(common.INVALID_MESSAGE, "socket doesn't support binary messages"), "", connection)
+				return
+			}
+
+			msg, err = connection.codec.Decode(msg)
 			if err != nil {
-				// log.Printf("Error %s when sending message to client", err)
+				SendErrorMessage(common.NewSocketError(common.INVALID_MESSAGE, "Invalid message"), "", connection)
+				continue
 			}
-			return
 		}
 
-		textMessage := string(msg)
+		handleMessage(connection, ctx, msg)
+	}
+}
 
-		req := common.SocketMessage{}
-		parsedReq, err := req.ToObject(textMessage)
-		if err != nil {
-			SendErrorMessage("Invalid message", c)
-			continue
-		}
-		req = parsedReq.(common.SocketMessage)
-		// log.Printf("Receive message %s - %s", req.GetEventName(), string(msg))
-		processError := gameService.ProcessMessage(boardId, playerId, req, msg)
-		if processError != nil {
-			// log.Printf("Error %s when processing message", processError)
-			SendErrorMessage(processError.Error(), c)
+// handleMessage hands a JSON message of the client to the game service of the connection, whatever the transport
+// it came through. The errors are sent back on the connection.
+func handleMessage(connection *Connection, ctx *ConnectionContext, msg []byte) {
+	req := common.SocketMessage{}
+	parsedReq, err := req.ToObject(string(msg))
+	if err != nil {
+		SendErrorMessage(common.NewSocketError(common.INVALID_MESSAGE, "Invalid message"), requestIdOf(msg), connection)
+		return
+	}
+	req = parsedReq.(common.SocketMessage)
+	// log.Printf("Receive message %s - %s", req.GetEventName(), string(msg))
+	processError := ctx.Service.ProcessMessage(ctx.BoardId, ctx.PlayerId, req, msg)
+	if processError != nil {
+		// log.Printf("Error %s when processing message", processError)
+		SendErrorMessage(processError, req.GetRequestId(), connection)
+	}
+}
+
+// serveSpectator keeps a spectator connection open until it is closed.
+// Spectators receive the board broadcasts and the spectator-only events, their own messages are ignored.
+func serveSpectator(connection *Connection, ctx *ConnectionContext) {
+	c := connection.Conn
+	boardId, playerId := ctx.BoardId, ctx.PlayerId
+
+	sessions.addSpectator(boardId, connection)
+	log.Printf("[@serveSpectator] Spectator %s is watching board %s", playerId, boardId)
+
+	c.SetPongHandler(func(appData string) error {
+		c.SetReadDeadline(time.Now().Add(readWait))
+		return nil
+	})
+
+	c.SetReadDeadline(time.Now().Add(readWait))
+
+	defer func() {
+		sessions.removeSpectator(boardId, connection)
+		connection.Close()
+	}()
+
+	for {
+		if _, _, err := c.ReadMessage(); err != nil {
+			return
 		}
+		c.SetReadDeadline(time.Now().Add(readWait))
 	}
 }
 
@@ -206,15 +226,45 @@ func StartSocketServer(port int, gsMap map[string]common.GameService) error {
 	gameServiceMap = gsMap
 
 	http.Handle("/ws", webSocketHandler)
+
+	// Clients whose network blocks websockets receive the events on /sse and POST their messages to /sse/message
+	http.Handle("/sse", eventStreamHandler{})
+	http.HandleFunc("/sse/message", handleStreamCommand)
+	http.HandleFunc("/metrics/socket", handleMetrics)
 	http.HandleFunc("/", handleHome)
 
 	log.Printf("Starting socket socket on port %d...", port)
-	log.Fatal(http.ListenAndServe(":"+strconv.Itoa(port), nil))
+	server.Addr = ":" + strconv.Itoa(port)
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
 	return nil
 }
 
+// clientIP returns the IP the request comes from, the first forwarded one behind a proxy
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// RemoteAddress returns the IP of the connection of the player to the board, empty for in-process players
+func RemoteAddress(playerId string, boardId string) string {
+	if connection := sessions.lookup(playerId, boardId); connection != nil {
+		return connection.remoteAddress
+	}
+	return ""
+}
+
 func SendMessage(playerId string, msg common.Message, boardId string) {
-	if playerConnections[playerId] == nil {
+	connection := sessions.lookup(playerId, boardId)
+	if connection == nil {
 		// log.Printf("Player %s is not connected", playerId)
 		return
 	}
@@ -226,10 +276,8 @@ func SendMessage(playerId string, msg common.Message, boardId string) {
 		// log.Printf("Error %s when marshalling message", err)
 		return
 	}
-	if connections := playerConnections[playerId]; len(connections) > 0 {
-		writeResponse(body, connections[len(connections)-1].Conn)
-		log.Printf("[%s] Sent Message: [%s] to Player [%s]", boardId, body, playerId)
-	}
+	connection.write(body)
+	log.Printf("[%s] Sent Message: [%s] to Player [%s]", boardId, body, playerId)
 }
 
 func BroadcastMessage(msg common.Message, boardId string) {
@@ -243,78 +291,96 @@ func BroadcastMessage(msg common.Message, boardId string) {
 		return
 	}
 
-	playerIds := boardPlayerMap[boardId]
-
-	if playerIds == nil {
-		// log.Printf("No players in board %s", boardId)
-		return
+	// Send to the players of the board
+	for playerId, connection := range sessions.players(boardId) {
+		connection.write(body)
+		log.Printf("[%s] Sent Message: [%s] to Player [%s]", boardId, body, playerId)
 	}
 
-	// Send to specified players
-	for _, playerId := range playerIds {
-		if _, ok := playerConnections[playerId]; ok {
-			if connections := playerConnections[playerId]; len(connections) > 0 {
-				writeResponse(body, connections[len(connections)-1].Conn)
-				log.Printf("[%s] Sent Message: [%s] to Player [%s]", boardId, body, playerId)
-			}
-		}
+	for _, spectator := range sessions.boardSpectators(boardId) {
+		spectator.write(body)
 	}
 }
 
-func handleDisconnection(c *websocket.Conn, boardId string, playerId string) {
-	log.Print("Handling disconnection...")
-	if c == nil {
-		log.Println("Connection is nil")
+// BroadcastToSpectators sends a message to the spectators of a board only
+func BroadcastToSpectators(msg common.Message, boardId string) {
+	spectators := sessions.boardSpectators(boardId)
+
+	if len(spectators) == 0 {
 		return
 	}
 
-	// Get the correct game service instance
-	gameService := gameServiceMap["ludo"] // Or pass it through parameters
-	if gameService == nil {
+	body, err := msg.ToJSON()
+
+	if err != nil {
+		log.Printf("Error %s when marshalling message", err)
+		return
+	}
 
-		log.Println("Game service is nil ")
-		gameService = gameServiceMap["ludo"] // Default fallback
+	for _, spectator := range spectators {
+		spectator.write(body)
 	}
+}
 
-	connections := playerConnections[playerId]
-	if len(connections) == 0 {
-		log.Printf("No connections found for player %s", playerId)
+// HasSpectators reports whether anyone is watching the board
+func HasSpectators(boardId string) bool {
+	return len(sessions.boardSpectators(boardId)) > 0
+}
+
+// AttachVirtualPlayer registers an in-process player (e.g. a bot) on a board.
+// The player is addressed exactly like a websocket player by SendMessage and BroadcastMessage,
+// but every message is delivered to the receiver.
+func AttachVirtualPlayer(playerId string, boardId string, receiver func(string)) {
+	if _, err := sessions.register(playerId, boardId, NewVirtualConnection(receiver)); err != nil {
+		log.Printf("[@AttachVirtualPlayer] %s", err)
 		return
 	}
+	log.Printf("[@AttachVirtualPlayer] Added virtual player %s to board %s", playerId, boardId)
+}
 
-	// Find and remove the specific connection
-	newConnections := make([]*Connection, 0)
-	for _, conn := range connections {
-		if conn.Conn != c {
-			newConnections = append(newConnections, conn)
-		}
+// DetachVirtualPlayer removes an in-process player from a board without triggering disconnection handling
+func DetachVirtualPlayer(playerId string, boardId string) {
+	if connection := sessions.lookup(playerId, boardId); connection != nil {
+		sessions.unregister(playerId, boardId, connection)
 	}
+}
 
-	if len(newConnections) == 0 {
-		// Last connection removed
-		gameService.HandleDisconnection(boardId, playerId)
-		delete(playerConnections, playerId)
-		// Remove from board player map
-		boardPlayerMap[boardId] = removeFromSlice(boardPlayerMap[boardId], playerId)
+func handleDisconnection(c *Connection) {
+	log.Print("Handling disconnection...")
+	if c == nil {
+		log.Println("Connection is nil")
+		return
+	}
+
+	ctx := c.Context()
+
+	// A connection replaced by a newer one to the same board leaves without disconnecting the player, and so does a
+	// connection closed by the shutdown, the game was stored as it is
+	if sessions.unregister(ctx.PlayerId, ctx.BoardId, c) {
+		if IsDraining() {
+			log.Printf("Connection of player %s to board %s closed by the shutdown", ctx.PlayerId, ctx.BoardId)
+		} else {
+			ctx.Service.HandleDisconnection(ctx.BoardId, ctx.PlayerId)
+		}
 	} else {
-		playerConnections[playerId] = newConnections
+		log.Printf("Connection of player %s to board %s was replaced, the player stays connected", ctx.PlayerId, ctx.BoardId)
 	}
 
 	c.Close()
 }
 
-// Helper function to remove from slice
-func removeFromSlice(slice []string, item string) []string {
-	newSlice := make([]string, 0)
-	for _, i := range slice {
-		if i != item {
-			newSlice = append(newSlice, i)
-		}
-	}
-	return newSlice
+// SendErrorMessage sends the error to the connection with its code in the catalog, errors outside the catalog are
+// internal errors. The request id is the one of the message that failed, empty when the error is not a reply.
+func SendErrorMessage(err error, requestId string, c *Connection) {
+	errMsg, _ := common.NewErrorMessage(err, requestId).ToJSON()
+	c.write(errMsg)
 }
 
-func SendErrorMessage(errorMessage string, c *websocket.Conn) {
-	errMsg, _ := common.NewSocketMessage("error", 500, errorMessage).ToJSON()
-	writeResponse(errMsg, c)
+// requestIdOf returns the request id of a message that could not be parsed, so its error can still be matched
+func requestIdOf(msg []byte) string {
+	var request struct {
+		RequestId string `json:"requestId"`
+	}
+	json.Unmarshal(msg, &request)
+	return request.RequestId
 }
@@ -1,6 +1,10 @@
 package rng
 
-import "math/rand"
+import (
+	crand "crypto/rand"
+	"math/big"
+	"math/rand"
+)
 
 var rollToggle = false
 
@@ -16,3 +20,13 @@ func (rng *RNG) GenerateRandomNumber() int {
 
 	return val
 }
+
+// Intn returns a uniformly distributed number in [0, n), for draws that have to be fair such as the seating order
+func (rng *RNG) Intn(n int) int {
+	value, err := crand.Int(crand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return rand.Intn(n)
+	}
+
+	return int(value.Int64())
+}
@@ -0,0 +1,497 @@
+package board
+
+import (
+	"fmt"
+	"log"
+	"messaging/common"
+	"metagame/gameserver/wallet"
+	"rng"
+	"snakes/snakes_board_constants"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Player is a player seated on the board
+type Player struct {
+	ID            string
+	Name          string
+	WalletAddress string
+	Position      int // Square of the token, 0 until the first roll moves it onto the board
+	Connected     bool
+}
+
+// Board is a Snakes and Ladders game, the players roll in turn and the first token on the last square wins the pot
+type Board struct {
+	id                         string
+	players                    []*Player // In the order they roll
+	status                     snakes_board_constants.BoardStatus
+	ticketAmount               int
+	rakePercentage             int
+	playersRequiredToStartGame int
+	currentTurn                int  // Index of the player to roll
+	turn                       int  // Counts the turns, so the timer of a turn that is over never rolls
+	drained                    bool // The server is shutting down, the game is frozen at its stored state
+	winner                     string
+	winningAmount              int
+	mu                         sync.Mutex
+	transport                  Transport
+	store                      Store
+	wallet                     Wallet
+	dice                       func() int
+	turnTimeout                time.Duration // Time a connected player has to roll
+	autoRollDelay              time.Duration // Time before the server rolls for a disconnected player
+}
+
+// NewBoard creates a WAITING board and stores it
+func NewBoard(boardId string, playersRequiredToStartGame int, ticketAmount int, rakePercentage int) *Board {
+	b := newBoard(boardId, playersRequiredToStartGame, ticketAmount, rakePercentage)
+
+	err := b.store.InsertBoard(BoardSchema{
+		ID:                         primitive.NewObjectID().Hex(),
+		BoardId:                    boardId,
+		TicketAmount:               ticketAmount,
+		RakePercentage:             rakePercentage,
+		Status:                     snakes_board_constants.WAITING,
+		PlayersRequiredToStartGame: playersRequiredToStartGame,
+		Players:                    []PlayerSchema{},
+		Moves:                      []MoveSchema{},
+	})
+	if err != nil {
+		log.Printf("Error storing board %s: %v", boardId, err)
+	}
+
+	return b
+}
+
+func newBoard(boardId string, playersRequiredToStartGame int, ticketAmount int, rakePercentage int) *Board {
+	return &Board{
+		id:                         boardId,
+		players:                    []*Player{},
+		status:                     snakes_board_constants.WAITING,
+		ticketAmount:               ticketAmount,
+		rakePercentage:             rakePercentage,
+		playersRequiredToStartGame: playersRequiredToStartGame,
+		transport:                  socketTransport{},
+		store:                      mongoStore{},
+		wallet:                     wallet.NewPlatformWallet(snakes_board_constants.GAME),
+		dice:                       rollDice,
+		turnTimeout:                snakes_board_constants.TURN_TIMEOUT,
+		autoRollDelay:              snakes_board_constants.AUTO_ROLL_DELAY,
+	}
+}
+
+// rollDice returns a fair roll of the die
+func rollDice() int {
+	return (&rng.RNG{}).Intn(snakes_board_constants.DICE_FACES) + 1
+}
+
+// Advance returns the square a token on position ends on after rolling value, and the snake or ladder that moved it.
+// A roll past the last square leaves the token where it is, the last square has to be reached exactly.
+func Advance(position int, value int) (int, snakes_board_constants.Jump) {
+	target := position + value
+
+	if target > snakes_board_constants.BOARD_SIZE {
+		return position, snakes_board_constants.JUMP_NONE
+	}
+
+	if tail, ok := snakes_board_constants.SNAKES[target]; ok {
+		return tail, snakes_board_constants.JUMP_SNAKE
+	}
+
+	if top, ok := snakes_board_constants.LADDERS[target]; ok {
+		return top, snakes_board_constants.JUMP_LADDER
+	}
+
+	return target, snakes_board_constants.JUMP_NONE
+}
+
+// SetTransport replaces the websocket server the board messages are sent through
+func (b *Board) SetTransport(transport Transport) {
+	b.transport = transport
+}
+
+// SetStore replaces the MongoDB persistence of the board
+func (b *Board) SetStore(store Store) {
+	b.store = store
+}
+
+// SetWallet replaces the platform wallet API for bets, wins and refunds
+func (b *Board) SetWallet(wallet Wallet) {
+	b.wallet = wallet
+}
+
+// SetDice replaces the die the players roll
+func (b *Board) SetDice(dice func() int) {
+	b.dice = dice
+}
+
+// SetTurnTimeouts sets the time a connected player has to roll and the time before the server rolls for a disconnected one
+func (b *Board) SetTurnTimeouts(turnTimeout time.Duration, autoRollDelay time.Duration) {
+	b.turnTimeout = turnTimeout
+	b.autoRollDelay = autoRollDelay
+}
+
+func (b *Board) Lock() {
+	b.mu.Lock()
+}
+
+func (b *Board) Unlock() {
+	b.mu.Unlock()
+}
+
+func (b *Board) GetID() string {
+	return b.id
+}
+
+func (b *Board) GetBoardStatus() snakes_board_constants.BoardStatus {
+	return b.status
+}
+
+func (b *Board) GetPlayers() []*Player {
+	return b.players
+}
+
+func (b *Board) GetMaxPlayers() int {
+	return b.playersRequiredToStartGame
+}
+
+func (b *Board) GetTicketAmount() int {
+	return b.ticketAmount
+}
+
+func (b *Board) GetRakePercentage() int {
+	return b.rakePercentage
+}
+
+// GetWinner returns the player whose token reached the last square, empty until the game is over
+func (b *Board) GetWinner() string {
+	return b.winner
+}
+
+func (b *Board) GetWinningAmount() int {
+	return b.winningAmount
+}
+
+// GetCurrentTurn returns the player to roll, empty unless the game is being played
+func (b *Board) GetCurrentTurn() string {
+	if b.status != snakes_board_constants.PLAYING {
+		return ""
+	}
+	return b.players[b.currentTurn].ID
+}
+
+// GetPlayer returns the player seated on the board with the given id, nil if there is none
+func (b *Board) GetPlayer(playerId string) *Player {
+	for _, p := range b.players {
+		if p.ID == playerId {
+			return p
+		}
+	}
+	return nil
+}
+
+// AddPlayer seats a player on a WAITING board once its ticket is paid, and starts the game when the board is full.
+// A player already seated is reconnected instead.
+func (b *Board) AddPlayer(playerId string, name string, walletAddress string) error {
+	if existingPlayer := b.GetPlayer(playerId); existingPlayer != nil {
+		b.handleReconnection(existingPlayer)
+		return nil
+	}
+
+	if b.status != snakes_board_constants.WAITING {
+		return common.NewSocketError(common.BOARD_CLOSED, "game Already started. Not Accepting new players")
+	}
+
+	if len(b.players) == b.playersRequiredToStartGame {
+		return common.NewSocketError(common.BOARD_FULL, "game has reached maximum players. Not Accepting new players")
+	}
+
+	if b.ticketAmount > 0 {
+		if err := b.wallet.Bet(walletAddress, float64(b.ticketAmount)); err != nil {
+			return fmt.Errorf("bet failed: %w", err)
+		}
+	}
+
+	b.players = append(b.players, &Player{
+		ID:            playerId,
+		Name:          name,
+		WalletAddress: walletAddress,
+		Connected:     true,
+	})
+
+	err := b.store.AddPlayer(b.id, PlayerSchema{
+		PlayerId:      playerId,
+		Name:          name,
+		WalletAddress: walletAddress,
+		JoinedAt:      time.Now(),
+	})
+	if err != nil {
+		log.Printf("Error storing player %s of board %s: %v", playerId, b.id, err)
+	}
+
+	b.broadcastState()
+
+	if len(b.players) == b.playersRequiredToStartGame {
+		b.start()
+	}
+
+	return nil
+}
+
+// handleReconnection sends the board back to a player that connected again, and gives it its turn back in full
+func (b *Board) handleReconnection(existingPlayer *Player) {
+	log.Printf("[AddPlayer] Player %s reconnected to board %s", existingPlayer.ID, b.id)
+
+	existingPlayer.Connected = true
+	b.store.UpdatePlayerConnectionDetails(b.id, existingPlayer.ID, "reconnection", time.Now())
+
+	b.broadcastState()
+
+	if b.status == snakes_board_constants.PLAYING && b.players[b.currentTurn] == existingPlayer {
+		b.beginTurn()
+	}
+}
+
+// HandleDisconnection refunds and unseats a player that leaves a WAITING board.
+// On a board being played the server rolls for the player until it reconnects, and the game is discarded once nobody is connected.
+func (b *Board) HandleDisconnection(playerId string) error {
+	p := b.GetPlayer(playerId)
+	if p == nil {
+		return fmt.Errorf("player %s is not on board %s", playerId, b.id)
+	}
+
+	switch b.status {
+	case snakes_board_constants.WAITING:
+		b.removePlayer(p)
+	case snakes_board_constants.PLAYING:
+		p.Connected = false
+		b.store.UpdatePlayerConnectionDetails(b.id, playerId, "disconnection", time.Now())
+
+		if !b.anyConnected() {
+			b.discard()
+			return nil
+		}
+
+		b.broadcastState()
+
+		if b.players[b.currentTurn] == p {
+			b.beginTurn()
+		}
+	default:
+		p.Connected = false
+	}
+
+	return nil
+}
+
+func (b *Board) anyConnected() bool {
+	for _, p := range b.players {
+		if p.Connected {
+			return true
+		}
+	}
+	return false
+}
+
+// removePlayer gives the player its ticket back and frees its seat
+func (b *Board) removePlayer(p *Player) {
+	b.refund(p)
+
+	players := []*Player{}
+	for _, seated := range b.players {
+		if seated != p {
+			players = append(players, seated)
+		}
+	}
+	b.players = players
+
+	b.store.RemovePlayer(b.id, p.ID)
+	b.broadcastState()
+}
+
+func (b *Board) refund(p *Player) {
+	if b.ticketAmount == 0 {
+		return
+	}
+
+	if err := b.wallet.Refund(p.ID, float64(b.ticketAmount), b.id); err != nil {
+		log.Printf("Error refunding player %s of board %s: %v", p.ID, b.id, err)
+	}
+}
+
+// discard ends a game nobody is connected to any more and refunds every player
+func (b *Board) discard() {
+	log.Printf("All players left board %s, discarding it", b.id)
+
+	b.status = snakes_board_constants.DISCARDED
+	b.turn++
+
+	for _, p := range b.players {
+		b.refund(p)
+	}
+
+	b.store.UpdateStatus(b.id, snakes_board_constants.DISCARDED, time.Now())
+}
+
+// Drain closes a WAITING board to new players when the server shuts down. Its game would never start, so the
+// players are refunded and unseated. A board being played is frozen first, the timer of the turn stops and no
+// roll is accepted, then its state is stored. Restoring the board from the stored state is out of scope.
+func (b *Board) Drain() error {
+	switch b.status {
+	case snakes_board_constants.WAITING:
+		for _, p := range append([]*Player(nil), b.players...) {
+			b.removePlayer(p)
+		}
+
+		b.status = snakes_board_constants.UNAVAILABLE
+
+		return b.store.UpdateStatus(b.id, snakes_board_constants.UNAVAILABLE, time.Now())
+	case snakes_board_constants.PLAYING:
+		b.drained = true
+		b.turn++
+
+		positions := map[string]int{}
+		for _, p := range b.players {
+			positions[p.ID] = p.Position
+		}
+
+		return b.store.SaveState(b.id, StateSchema{
+			CurrentTurn: b.GetCurrentTurn(),
+			Positions:   positions,
+			SavedAt:     time.Now(),
+		})
+	}
+
+	return nil
+}
+
+func (b *Board) start() {
+	log.Printf("All players have joined the board %s, starting the game", b.id)
+
+	b.status = snakes_board_constants.PLAYING
+	b.store.UpdateStatus(b.id, snakes_board_constants.PLAYING, time.Now())
+
+	order := []string{}
+	for _, p := range b.players {
+		order = append(order, p.ID)
+	}
+	b.transport.Broadcast(NewGameStartMessage(snakes_board_constants.GAME_START, order), b.id)
+
+	b.currentTurn = 0
+	b.beginTurn()
+}
+
+// beginTurn tells the players whose roll it is, and rolls for that player once its time is up
+func (b *Board) beginTurn() {
+	b.turn++
+	turn := b.turn
+
+	p := b.players[b.currentTurn]
+
+	timeout := b.turnTimeout
+	if !p.Connected {
+		timeout = b.autoRollDelay
+	}
+
+	b.transport.Broadcast(NewTurnMessage(snakes_board_constants.TURN, p.ID, int(timeout.Seconds())), b.id)
+
+	time.AfterFunc(timeout, func() {
+		b.Lock()
+		defer b.Unlock()
+
+		if b.turn == turn && b.status == snakes_board_constants.PLAYING {
+			b.roll(p, true)
+		}
+	})
+}
+
+// RollDice rolls for the player whose turn it is
+func (b *Board) RollDice(playerId string) error {
+	if b.status != snakes_board_constants.PLAYING {
+		return common.NewSocketError(common.INVALID_TURN, fmt.Sprintf("game on board %s is not being played", b.id))
+	}
+
+	if b.drained {
+		return common.NewSocketError(common.SERVER_DRAINING, fmt.Sprintf("board %s is frozen while the server shuts down", b.id))
+	}
+
+	p := b.players[b.currentTurn]
+	if p.ID != playerId {
+		return common.NewSocketError(common.INVALID_TURN, fmt.Sprintf("invalid player %s, it is the turn of player %s", playerId, p.ID))
+	}
+
+	b.roll(p, false)
+	return nil
+}
+
+// roll moves the token of the player by a roll of the die. The highest face rolls again, unless the token wins.
+func (b *Board) roll(p *Player, autoPlayed bool) {
+	value := b.dice()
+	from := p.Position
+	to, jump := Advance(from, value)
+	p.Position = to
+
+	err := b.store.AddMove(b.id, MoveSchema{
+		PlayerId:   p.ID,
+		Value:      value,
+		From:       from,
+		To:         to,
+		Jump:       jump,
+		AutoPlayed: autoPlayed,
+		Timestamp:  time.Now(),
+	})
+	if err != nil {
+		log.Printf("Error storing move of player %s on board %s: %v", p.ID, b.id, err)
+	}
+
+	b.transport.Broadcast(NewDiceRolledMessage(snakes_board_constants.BOARD_DICEROLLED, p.ID, value, from, to, string(jump), autoPlayed), b.id)
+
+	if to == snakes_board_constants.BOARD_SIZE {
+		b.finish(p)
+		return
+	}
+
+	if value != snakes_board_constants.DICE_FACES {
+		b.currentTurn = (b.currentTurn + 1) % len(b.players)
+	}
+
+	b.beginTurn()
+}
+
+// finish pays the pot, less the rake, to the player whose token reached the last square
+func (b *Board) finish(winner *Player) {
+	b.status = snakes_board_constants.FINISHED
+	b.turn++
+
+	pot := b.ticketAmount * len(b.players)
+	b.winner = winner.ID
+	b.winningAmount = pot - pot*b.rakePercentage/100
+
+	if b.ticketAmount > 0 {
+		if err := b.wallet.Win(winner.WalletAddress, float64(b.winningAmount)); err != nil {
+			log.Printf("Error paying player %s the pot of board %s: %v", winner.ID, b.id, err)
+		}
+	}
+
+	b.store.UpdateWinner(b.id, winner.ID, b.winningAmount)
+	b.store.UpdateStatus(b.id, snakes_board_constants.FINISHED, time.Now())
+
+	b.transport.Broadcast(NewGameWinnerMessage(snakes_board_constants.GAME_WINNER, winner.ID, b.winningAmount), b.id)
+}
+
+// broadcastState sends the whole board to its players
+func (b *Board) broadcastState() {
+	players := []PlayerState{}
+	for _, p := range b.players {
+		players = append(players, PlayerState{
+			PlayerId:  p.ID,
+			Name:      p.Name,
+			Position:  p.Position,
+			Connected: p.Connected,
+		})
+	}
+
+	b.transport.Broadcast(NewBoardStateMessage(snakes_board_constants.BOARD_STATE, b.id, string(b.status), b.ticketAmount, players, b.GetCurrentTurn()), b.id)
+}
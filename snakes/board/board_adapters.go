@@ -0,0 +1,79 @@
+package board
+
+import (
+	"messaging/common"
+	"messaging/socket"
+	"snakes/snakes_board_constants"
+	"time"
+)
+
+// Transport delivers the board messages to the players
+type Transport interface {
+	Send(playerId string, msg common.Message, boardId string)
+	Broadcast(msg common.Message, boardId string)
+}
+
+// Store persists what happens on the board
+type Store interface {
+	InsertBoard(board BoardSchema) error
+	AddPlayer(boardId string, player PlayerSchema) error
+	RemovePlayer(boardId string, playerId string) error
+	UpdatePlayerConnectionDetails(boardId, playerId string, cType string, time time.Time) error
+	UpdateStatus(boardId string, status snakes_board_constants.BoardStatus, time time.Time) error
+	AddMove(boardId string, move MoveSchema) error
+	UpdateWinner(boardId string, winner string, winningAmount int) error
+	SaveState(boardId string, state StateSchema) error
+}
+
+// Wallet moves the players' money on the platform
+type Wallet interface {
+	Bet(walletAddress string, amount float64) error
+	Win(walletAddress string, amount float64) error
+	Refund(playerId string, amount float64, gameId string) error
+}
+
+// socketTransport sends the messages through the websocket server
+type socketTransport struct{}
+
+func (socketTransport) Send(playerId string, msg common.Message, boardId string) {
+	socket.SendMessage(playerId, msg, boardId)
+}
+
+func (socketTransport) Broadcast(msg common.Message, boardId string) {
+	socket.BroadcastMessage(msg, boardId)
+}
+
+// mongoStore persists the board in MongoDB
+type mongoStore struct{}
+
+func (mongoStore) InsertBoard(board BoardSchema) error {
+	return NewBoardDAO().InsertBoard(board)
+}
+
+func (mongoStore) AddPlayer(boardId string, player PlayerSchema) error {
+	return NewBoardDAO().AddPlayer(boardId, player)
+}
+
+func (mongoStore) RemovePlayer(boardId string, playerId string) error {
+	return NewBoardDAO().RemovePlayer(boardId, playerId)
+}
+
+func (mongoStore) UpdatePlayerConnectionDetails(boardId, playerId string, cType string, time time.Time) error {
+	return NewBoardDAO().UpdatePlayerConnectionDetails(boardId, playerId, cType, time)
+}
+
+func (mongoStore) UpdateStatus(boardId string, status snakes_board_constants.BoardStatus, time time.Time) error {
+	return NewBoardDAO().UpdateStatus(boardId, status, time)
+}
+
+func (mongoStore) AddMove(boardId string, move MoveSchema) error {
+	return NewBoardDAO().AddMove(boardId, move)
+}
+
+func (mongoStore) UpdateWinner(boardId string, winner string, winningAmount int) error {
+	return NewBoardDAO().UpdateWinner(boardId, winner, winningAmount)
+}
+
+func (mongoStore) SaveState(boardId string, state StateSchema) error {
+	return NewBoardDAO().UpdateState(boardId, state)
+}
@@ -0,0 +1,148 @@
+package board
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"metagame/gameserver/config"
+	"metagame/gameserver/helpers"
+	"snakes/snakes_board_constants"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type BoardDAO struct {
+	collection *mongo.Collection
+}
+
+func NewBoardDAO() *BoardDAO {
+	client := helpers.GetMongoClient()
+	collection := client.Database(config.GetConfig().Database).Collection("snakes_games")
+	return &BoardDAO{
+		collection: collection,
+	}
+}
+
+func (dao *BoardDAO) InsertBoard(board BoardSchema) error {
+	_, err := dao.collection.InsertOne(context.Background(), board)
+	if err != nil {
+		log.Printf("InsertBoard: Error inserting board: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (dao *BoardDAO) AddPlayer(boardId string, player PlayerSchema) error {
+	filter := bson.M{"boardId": boardId}
+
+	update := bson.M{"$push": bson.M{"players": player}}
+
+	_, err := dao.collection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		log.Printf("AddPlayer: Error adding player to boardId %s: %v", boardId, err)
+		return fmt.Errorf("failed to add player to game with ID %s: %v", boardId, err)
+	}
+
+	return nil
+}
+
+func (dao *BoardDAO) RemovePlayer(boardId string, playerId string) error {
+	filter := bson.M{"boardId": boardId}
+
+	update := bson.M{"$pull": bson.M{"players": bson.M{"playerId": playerId}}}
+
+	_, err := dao.collection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		log.Printf("RemovePlayer: Error removing player %s from boardId %s: %v", playerId, boardId, err)
+		return fmt.Errorf("failed to remove player %s from game with ID %s: %v", playerId, boardId, err)
+	}
+
+	return nil
+}
+
+func (dao *BoardDAO) UpdatePlayerConnectionDetails(boardId string, playerId string, cType string, time time.Time) error {
+	field := "disconnectedAt"
+
+	if cType == "reconnection" {
+		field = "reconnectedAt"
+	}
+
+	filter := bson.M{"boardId": boardId, "players.playerId": playerId}
+
+	update := bson.M{"$set": bson.M{fmt.Sprintf("players.$.%s", field): time}}
+
+	_, err := dao.collection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		log.Printf("UpdatePlayerConnectionDetails: Error updating %s of player %s in boardId %s: %v", field, playerId, boardId, err)
+		return fmt.Errorf("failed to update %s of player %s in game with ID %s: %v", field, playerId, boardId, err)
+	}
+
+	return nil
+}
+
+// UpdateStatus sets the status of the board, with its start time when it starts and its end time otherwise
+func (dao *BoardDAO) UpdateStatus(boardId string, status snakes_board_constants.BoardStatus, sTime time.Time) error {
+	field := "endTime"
+
+	if status == snakes_board_constants.PLAYING {
+		field = "startTime"
+	}
+
+	filter := bson.M{"boardId": boardId}
+
+	update := bson.M{"$set": bson.M{"status": status, field: sTime}}
+
+	_, err := dao.collection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		log.Printf("UpdateStatus: Error updating status for boardId %s: %v", boardId, err)
+		return fmt.Errorf("failed to update status for game with ID %s: %v", boardId, err)
+	}
+
+	return nil
+}
+
+func (dao *BoardDAO) AddMove(boardId string, move MoveSchema) error {
+	filter := bson.M{"boardId": boardId}
+
+	update := bson.M{"$push": bson.M{"moves": move}}
+
+	_, err := dao.collection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		log.Printf("AddMove: Error adding move to boardId %s: %v", boardId, err)
+		return fmt.Errorf("failed to add move to game with ID %s: %v", boardId, err)
+	}
+
+	return nil
+}
+
+func (dao *BoardDAO) UpdateWinner(boardId string, winner string, winningAmount int) error {
+	filter := bson.M{"boardId": boardId}
+
+	update := bson.M{"$set": bson.M{"winner": winner, "winningAmount": winningAmount}}
+
+	_, err := dao.collection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		log.Printf("UpdateWinner: Error updating winner for boardId %s: %v", boardId, err)
+		return fmt.Errorf("failed to update winner for game with ID %s: %v", boardId, err)
+	}
+
+	return nil
+}
+
+// UpdateState stores the state of a board being played
+func (dao *BoardDAO) UpdateState(boardId string, state StateSchema) error {
+	filter := bson.M{"boardId": boardId}
+
+	update := bson.M{"$set": bson.M{"state": state}}
+
+	_, err := dao.collection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		log.Printf("UpdateState: Error updating state of boardId %s: %v", boardId, err)
+		return fmt.Errorf("failed to update state of game with ID %s: %v", boardId, err)
+	}
+
+	return nil
+}
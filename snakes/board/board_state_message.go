@@ -0,0 +1,72 @@
+package board
+
+import (
+	"encoding/json"
+	"messaging/common"
+)
+
+// PlayerState is a player of the board with the square its token is on
+type PlayerState struct {
+	PlayerId  string `json:"playerId"`
+	Name      string `json:"name"`
+	Position  int    `json:"position"` // 0 until the first roll moves the token onto the board
+	Connected bool   `json:"connected"`
+}
+
+// BoardStateMessage is the whole board, sent to a player when it joins or reconnects and to everyone when the seating changes
+type BoardStateMessage struct {
+	common.Message
+	eventName    string
+	boardId      string
+	status       string
+	ticketAmount int
+	players      []PlayerState
+	currentTurn  string // Player to roll, empty unless the game is being played
+}
+
+// NewBoardStateMessage creates a new BoardStateMessage.
+func NewBoardStateMessage(eventName string, boardId string, status string, ticketAmount int, players []PlayerState, currentTurn string) *BoardStateMessage {
+	return &BoardStateMessage{
+		eventName:    eventName,
+		boardId:      boardId,
+		status:       status,
+		ticketAmount: ticketAmount,
+		players:      players,
+		currentTurn:  currentTurn,
+	}
+}
+
+type boardStateJSON struct {
+	EventName    string        `json:"eventName"`
+	BoardId      string        `json:"boardId"`
+	Status       string        `json:"status"`
+	TicketAmount int           `json:"ticketAmount"`
+	Players      []PlayerState `json:"players"`
+	CurrentTurn  string        `json:"currentTurn"`
+}
+
+// ToJSON returns the JSON representation of the BoardStateMessage.
+func (m *BoardStateMessage) ToJSON() (string, error) {
+	jsonData, err := json.Marshal(boardStateJSON{
+		EventName:    m.eventName,
+		BoardId:      m.boardId,
+		Status:       m.status,
+		TicketAmount: m.ticketAmount,
+		Players:      m.players,
+		CurrentTurn:  m.currentTurn,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+func (m *BoardStateMessage) ToObject(data string) (common.Message, error) {
+	var intermediate boardStateJSON
+
+	if err := json.Unmarshal([]byte(data), &intermediate); err != nil {
+		return &BoardStateMessage{}, err
+	}
+
+	return NewBoardStateMessage(intermediate.EventName, intermediate.BoardId, intermediate.Status, intermediate.TicketAmount, intermediate.Players, intermediate.CurrentTurn), nil
+}
@@ -0,0 +1,185 @@
+package board
+
+import (
+	"messaging/common"
+	"snakes/snakes_board_constants"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingTransport struct {
+	messages []common.Message
+}
+
+func (t *recordingTransport) Send(playerId string, msg common.Message, boardId string) {
+	t.messages = append(t.messages, msg)
+}
+
+func (t *recordingTransport) Broadcast(msg common.Message, boardId string) {
+	t.messages = append(t.messages, msg)
+}
+
+type memoryStore struct {
+	moves  []MoveSchema
+	states []StateSchema
+}
+
+func (s *memoryStore) InsertBoard(board BoardSchema) error                   { return nil }
+func (s *memoryStore) AddPlayer(boardId string, player PlayerSchema) error   { return nil }
+func (s *memoryStore) RemovePlayer(boardId string, playerId string) error    { return nil }
+func (s *memoryStore) UpdateWinner(boardId, winner string, amount int) error { return nil }
+func (s *memoryStore) UpdatePlayerConnectionDetails(boardId, playerId string, cType string, time time.Time) error {
+	return nil
+}
+func (s *memoryStore) UpdateStatus(boardId string, status snakes_board_constants.BoardStatus, time time.Time) error {
+	return nil
+}
+func (s *memoryStore) AddMove(boardId string, move MoveSchema) error {
+	s.moves = append(s.moves, move)
+	return nil
+}
+func (s *memoryStore) SaveState(boardId string, state StateSchema) error {
+	s.states = append(s.states, state)
+	return nil
+}
+
+type recordingWallet struct {
+	balance map[string]float64
+}
+
+func (w *recordingWallet) Bet(walletAddress string, amount float64) error {
+	w.balance[walletAddress] -= amount
+	return nil
+}
+
+func (w *recordingWallet) Win(walletAddress string, amount float64) error {
+	w.balance[walletAddress] += amount
+	return nil
+}
+
+func (w *recordingWallet) Refund(playerId string, amount float64, gameId string) error {
+	w.balance[playerId] += amount
+	return nil
+}
+
+func newTestBoard(rolls ...int) (*Board, *memoryStore, *recordingWallet) {
+	store := &memoryStore{}
+	wallet := &recordingWallet{balance: map[string]float64{}}
+
+	b := newBoard("board", 2, 100, 10)
+	b.SetTransport(&recordingTransport{})
+	b.SetStore(store)
+	b.SetWallet(wallet)
+	b.SetTurnTimeouts(time.Hour, time.Hour)
+	b.SetDice(func() int {
+		roll := rolls[0]
+		rolls = rolls[1:]
+		return roll
+	})
+
+	return b, store, wallet
+}
+
+func TestAdvance(t *testing.T) {
+	to, jump := Advance(0, 1)
+	assert.Equal(t, 38, to, "the ladder at 1 climbs to 38")
+	assert.Equal(t, snakes_board_constants.JUMP_LADDER, jump)
+
+	to, jump = Advance(10, 6)
+	assert.Equal(t, 6, to, "the snake at 16 slides to 6")
+	assert.Equal(t, snakes_board_constants.JUMP_SNAKE, jump)
+
+	to, jump = Advance(97, 5)
+	assert.Equal(t, 97, to, "a roll past the last square leaves the token where it is")
+	assert.Equal(t, snakes_board_constants.JUMP_NONE, jump)
+
+	to, _ = Advance(97, 3)
+	assert.Equal(t, snakes_board_constants.BOARD_SIZE, to)
+}
+
+func TestBoardPlaysToTheWinner(t *testing.T) {
+	// alice climbs 1 -> 38, bob climbs 4 -> 14, alice rolls a six to 44 and rolls again
+	b, store, wallet := newTestBoard(1, 4, 6, 2, 3)
+
+	assert.NoError(t, b.AddPlayer("alice", "Alice", "w-alice"))
+	assert.Equal(t, snakes_board_constants.WAITING, b.GetBoardStatus())
+	assert.NoError(t, b.AddPlayer("bob", "Bob", "w-bob"))
+	assert.Equal(t, snakes_board_constants.PLAYING, b.GetBoardStatus())
+	assert.Error(t, b.AddPlayer("carol", "Carol", "w-carol"), "a full board takes nobody else")
+
+	assert.Error(t, b.RollDice("bob"), "alice rolls first")
+	assert.NoError(t, b.RollDice("alice"))
+	assert.Equal(t, 38, b.GetPlayer("alice").Position)
+
+	assert.NoError(t, b.RollDice("bob"))
+	assert.Equal(t, 14, b.GetPlayer("bob").Position)
+
+	assert.NoError(t, b.RollDice("alice"))
+	assert.Equal(t, 44, b.GetPlayer("alice").Position)
+	assert.Equal(t, "alice", b.GetCurrentTurn(), "a six rolls again")
+
+	assert.NoError(t, b.RollDice("alice"))
+	assert.NoError(t, b.RollDice("bob"))
+	assert.Equal(t, 17, b.GetPlayer("bob").Position)
+	assert.Len(t, store.moves, 5)
+
+	b.GetPlayer("bob").Position = 95
+	b.SetDice(func() int { return 5 })
+	assert.NoError(t, b.RollDice("alice"))
+	assert.NoError(t, b.RollDice("bob"))
+
+	assert.Equal(t, snakes_board_constants.FINISHED, b.GetBoardStatus())
+	assert.Equal(t, "bob", b.GetWinner())
+	assert.Equal(t, 180, b.GetWinningAmount(), "the pot of 200 less the rake of 10%")
+	assert.Equal(t, 80.0, wallet.balance["w-bob"])
+	assert.Equal(t, -100.0, wallet.balance["w-alice"])
+	assert.Error(t, b.RollDice("alice"), "nobody rolls once the game is over")
+}
+
+func TestDisconnections(t *testing.T) {
+	b, _, wallet := newTestBoard()
+
+	assert.NoError(t, b.AddPlayer("alice", "Alice", "w-alice"))
+	assert.NoError(t, b.HandleDisconnection("alice"))
+	assert.Empty(t, b.GetPlayers(), "a player leaving a waiting board frees its seat")
+	assert.Equal(t, 0.0, wallet.balance["w-alice"]+wallet.balance["alice"], "and gets its ticket back")
+
+	assert.NoError(t, b.AddPlayer("alice", "Alice", "w-alice"))
+	assert.NoError(t, b.AddPlayer("bob", "Bob", "w-bob"))
+	assert.NoError(t, b.HandleDisconnection("alice"))
+	assert.Equal(t, snakes_board_constants.PLAYING, b.GetBoardStatus(), "the game goes on while someone is connected")
+
+	assert.NoError(t, b.AddPlayer("alice", "Alice", "w-alice"))
+	assert.True(t, b.GetPlayer("alice").Connected, "joining again reconnects")
+
+	assert.NoError(t, b.HandleDisconnection("alice"))
+	assert.NoError(t, b.HandleDisconnection("bob"))
+	assert.Equal(t, snakes_board_constants.DISCARDED, b.GetBoardStatus())
+	assert.Equal(t, 0.0, wallet.balance["w-bob"]+wallet.balance["bob"], "a discarded game refunds its players")
+}
+
+func TestDrain(t *testing.T) {
+	waiting, _, wallet := newTestBoard()
+
+	assert.NoError(t, waiting.AddPlayer("alice", "Alice", "w-alice"))
+	assert.NoError(t, waiting.Drain())
+	assert.Equal(t, snakes_board_constants.UNAVAILABLE, waiting.GetBoardStatus())
+	assert.Empty(t, waiting.GetPlayers())
+	assert.Equal(t, 100.0, wallet.balance["alice"], "the game never starts, the ticket is refunded")
+	assert.Error(t, waiting.AddPlayer("bob", "Bob", "w-bob"), "nobody sits at a board closed by the shutdown")
+
+	playing, store, _ := newTestBoard(1)
+
+	assert.NoError(t, playing.AddPlayer("alice", "Alice", "w-alice"))
+	assert.NoError(t, playing.AddPlayer("bob", "Bob", "w-bob"))
+	assert.NoError(t, playing.RollDice("alice"))
+	assert.NoError(t, playing.Drain())
+	assert.Equal(t, snakes_board_constants.PLAYING, playing.GetBoardStatus())
+	assert.Len(t, store.states, 1)
+	assert.Equal(t, "bob", store.states[0].CurrentTurn)
+	assert.Equal(t, map[string]int{"alice": 38, "bob": 0}, store.states[0].Positions)
+	assert.Error(t, playing.RollDice("bob"), "the stored state is the last one, no roll is accepted after it")
+	assert.Len(t, store.moves, 1)
+}
@@ -0,0 +1,68 @@
+package board
+
+import (
+	"encoding/json"
+	"messaging/common"
+)
+
+// DiceRolledMessage is a roll of a player and the square it took the token to
+type DiceRolledMessage struct {
+	common.Message
+	eventName  string
+	playerId   string
+	value      int
+	from       int
+	to         int
+	jump       string // SNAKE or LADDER when the token was moved by one, empty otherwise
+	autoPlayed bool
+}
+
+// NewDiceRolledMessage creates a new DiceRolledMessage.
+func NewDiceRolledMessage(eventName string, playerId string, value int, from int, to int, jump string, autoPlayed bool) *DiceRolledMessage {
+	return &DiceRolledMessage{
+		eventName:  eventName,
+		playerId:   playerId,
+		value:      value,
+		from:       from,
+		to:         to,
+		jump:       jump,
+		autoPlayed: autoPlayed,
+	}
+}
+
+type diceRolledJSON struct {
+	EventName  string `json:"eventName"`
+	PlayerId   string `json:"playerId"`
+	Value      int    `json:"value"`
+	From       int    `json:"from"`
+	To         int    `json:"to"`
+	Jump       string `json:"jump,omitempty"`
+	AutoPlayed bool   `json:"autoPlayed"`
+}
+
+// ToJSON returns the JSON representation of the DiceRolledMessage.
+func (m *DiceRolledMessage) ToJSON() (string, error) {
+	jsonData, err := json.Marshal(diceRolledJSON{
+		EventName:  m.eventName,
+		PlayerId:   m.playerId,
+		Value:      m.value,
+		From:       m.from,
+		To:         m.to,
+		Jump:       m.jump,
+		AutoPlayed: m.autoPlayed,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+func (m *DiceRolledMessage) ToObject(data string) (common.Message, error) {
+	var intermediate diceRolledJSON
+
+	if err := json.Unmarshal([]byte(data), &intermediate); err != nil {
+		return &DiceRolledMessage{}, err
+	}
+
+	return NewDiceRolledMessage(intermediate.EventName, intermediate.PlayerId, intermediate.Value, intermediate.From, intermediate.To, intermediate.Jump, intermediate.AutoPlayed), nil
+}
@@ -0,0 +1,48 @@
+package board
+
+import (
+	"encoding/json"
+	"messaging/common"
+)
+
+// GameStartMessage is sent to the players when the board is full, with the order they roll in
+type GameStartMessage struct {
+	common.Message
+	eventName string
+	order     []string
+}
+
+// NewGameStartMessage creates a new GameStartMessage.
+func NewGameStartMessage(eventName string, order []string) *GameStartMessage {
+	return &GameStartMessage{
+		eventName: eventName,
+		order:     order,
+	}
+}
+
+type gameStartJSON struct {
+	EventName string   `json:"eventName"`
+	Order     []string `json:"order"`
+}
+
+// ToJSON returns the JSON representation of the GameStartMessage.
+func (m *GameStartMessage) ToJSON() (string, error) {
+	jsonData, err := json.Marshal(gameStartJSON{
+		EventName: m.eventName,
+		Order:     m.order,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+func (m *GameStartMessage) ToObject(data string) (common.Message, error) {
+	var intermediate gameStartJSON
+
+	if err := json.Unmarshal([]byte(data), &intermediate); err != nil {
+		return &GameStartMessage{}, err
+	}
+
+	return NewGameStartMessage(intermediate.EventName, intermediate.Order), nil
+}
@@ -0,0 +1,52 @@
+package board
+
+import (
+	"encoding/json"
+	"messaging/common"
+)
+
+// GameWinnerMessage is sent to the players when a token reaches the last square
+type GameWinnerMessage struct {
+	common.Message
+	eventName     string
+	winner        string
+	winningAmount int
+}
+
+// NewGameWinnerMessage creates a new GameWinnerMessage.
+func NewGameWinnerMessage(eventName string, winner string, winningAmount int) *GameWinnerMessage {
+	return &GameWinnerMessage{
+		eventName:     eventName,
+		winner:        winner,
+		winningAmount: winningAmount,
+	}
+}
+
+type gameWinnerJSON struct {
+	EventName     string `json:"eventName"`
+	Winner        string `json:"winner"`
+	WinningAmount int    `json:"winningAmount"`
+}
+
+// ToJSON returns the JSON representation of the GameWinnerMessage.
+func (m *GameWinnerMessage) ToJSON() (string, error) {
+	jsonData, err := json.Marshal(gameWinnerJSON{
+		EventName:     m.eventName,
+		Winner:        m.winner,
+		WinningAmount: m.winningAmount,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+func (m *GameWinnerMessage) ToObject(data string) (common.Message, error) {
+	var intermediate gameWinnerJSON
+
+	if err := json.Unmarshal([]byte(data), &intermediate); err != nil {
+		return &GameWinnerMessage{}, err
+	}
+
+	return NewGameWinnerMessage(intermediate.EventName, intermediate.Winner, intermediate.WinningAmount), nil
+}
@@ -0,0 +1,52 @@
+package board
+
+import (
+	"snakes/snakes_board_constants"
+	"time"
+)
+
+// PlayerSchema is a player seated on the board
+type PlayerSchema struct {
+	PlayerId       string     `bson:"playerId" json:"playerId"`
+	Name           string     `bson:"name" json:"name"`
+	WalletAddress  string     `bson:"walletAddress,omitempty" json:"walletAddress,omitempty"`
+	JoinedAt       time.Time  `bson:"joinedAt" json:"joinedAt"`
+	DisconnectedAt *time.Time `bson:"disconnectedAt,omitempty" json:"disconnectedAt,omitempty"`
+	ReconnectedAt  *time.Time `bson:"reconnectedAt,omitempty" json:"reconnectedAt,omitempty"`
+}
+
+// MoveSchema is a roll of a player and where it took the token
+type MoveSchema struct {
+	PlayerId   string                      `bson:"playerId" json:"playerId"`
+	Value      int                         `bson:"value" json:"value"`
+	From       int                         `bson:"from" json:"from"`
+	To         int                         `bson:"to" json:"to"`
+	Jump       snakes_board_constants.Jump `bson:"jump,omitempty" json:"jump,omitempty"`
+	AutoPlayed bool                        `bson:"autoPlayed" json:"autoPlayed"` // Rolled by the server when the player did not
+	Timestamp  time.Time                   `bson:"timestamp" json:"timestamp"`
+}
+
+// StateSchema is the state of a board being played, stored when the server shuts down.
+// Nothing reads it back, restoring the boards is out of scope.
+type StateSchema struct {
+	CurrentTurn string         `bson:"currentTurn" json:"currentTurn"` // Player to roll
+	Positions   map[string]int `bson:"positions" json:"positions"`     // Square of the token of every player
+	SavedAt     time.Time      `bson:"savedAt" json:"savedAt"`
+}
+
+// BoardSchema is a Snakes and Ladders game
+type BoardSchema struct {
+	ID                         string                             `bson:"_id" json:"_id"`
+	BoardId                    string                             `bson:"boardId" json:"boardId"`
+	TicketAmount               int                                `bson:"ticketAmount" json:"ticketAmount"`
+	RakePercentage             int                                `bson:"rakePercentage" json:"rakePercentage"`
+	WinningAmount              int                                `bson:"winningAmount" json:"winningAmount"`
+	Status                     snakes_board_constants.BoardStatus `bson:"status" json:"status"`
+	PlayersRequiredToStartGame int                                `bson:"playersRequiredToStartGame" json:"playersRequiredToStartGame"`
+	StartTime                  *time.Time                         `bson:"startTime,omitempty" json:"startTime,omitempty"`
+	EndTime                    *time.Time                         `bson:"endTime,omitempty" json:"endTime,omitempty"`
+	Winner                     *string                            `bson:"winner,omitempty" json:"winner,omitempty"`
+	Players                    []PlayerSchema                     `bson:"players" json:"players"`
+	Moves                      []MoveSchema                       `bson:"moves" json:"moves"`
+	State                      *StateSchema                       `bson:"state,omitempty" json:"state,omitempty"` // Stored when the server shut down during the game
+}
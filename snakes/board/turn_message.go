@@ -0,0 +1,52 @@
+package board
+
+import (
+	"encoding/json"
+	"messaging/common"
+)
+
+// TurnMessage tells the players whose roll it is and how long it has before the server rolls for it
+type TurnMessage struct {
+	common.Message
+	eventName string
+	playerId  string
+	timeout   int // Seconds
+}
+
+// NewTurnMessage creates a new TurnMessage.
+func NewTurnMessage(eventName string, playerId string, timeout int) *TurnMessage {
+	return &TurnMessage{
+		eventName: eventName,
+		playerId:  playerId,
+		timeout:   timeout,
+	}
+}
+
+type turnJSON struct {
+	EventName string `json:"eventName"`
+	PlayerId  string `json:"playerId"`
+	Timeout   int    `json:"timeout"`
+}
+
+// ToJSON returns the JSON representation of the TurnMessage.
+func (m *TurnMessage) ToJSON() (string, error) {
+	jsonData, err := json.Marshal(turnJSON{
+		EventName: m.eventName,
+		PlayerId:  m.playerId,
+		Timeout:   m.timeout,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+func (m *TurnMessage) ToObject(data string) (common.Message, error) {
+	var intermediate turnJSON
+
+	if err := json.Unmarshal([]byte(data), &intermediate); err != nil {
+		return &TurnMessage{}, err
+	}
+
+	return NewTurnMessage(intermediate.EventName, intermediate.PlayerId, intermediate.Timeout), nil
+}
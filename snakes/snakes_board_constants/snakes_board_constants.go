@@ -0,0 +1,81 @@
+package snakes_board_constants
+
+import "time"
+
+// GAME is the name of the game, the game services are registered as it and the platform wallet books its bets and wins under it
+const GAME = "snakes"
+
+type BoardStatus string
+
+const (
+	WAITING   BoardStatus = "WAITING"   // Waiting for players
+	PLAYING   BoardStatus = "PLAYING"   // Game in progress
+	FINISHED  BoardStatus = "FINISHED"  // Game completed
+	DISCARDED BoardStatus = "DISCARDED" // Game discarded
+
+	UNAVAILABLE BoardStatus = "UNAVAILABLE" // Closed to new players while the server shuts down, the game never starts
+)
+
+const (
+	BOARD_STATE      = "Board.State"
+	GAME_START       = "Game.Start"
+	TURN             = "Turn"
+	BOARD_DICEROLL   = "Board.DiceRoll"
+	BOARD_DICEROLLED = "Board.DiceRolled"
+	GAME_WINNER      = "Game.Winner"
+)
+
+// Jump is what a token landing on a square is moved by
+type Jump string
+
+const (
+	JUMP_NONE   Jump = ""
+	JUMP_SNAKE  Jump = "SNAKE"  // Down from the head of a snake to its tail
+	JUMP_LADDER Jump = "LADDER" // Up from the foot of a ladder to its top
+)
+
+const (
+	BOARD_SIZE = 100 // Squares of the board, the last one wins
+	DICE_FACES = 6   // Rolling the highest face gives another roll
+)
+
+// SNAKES are the squares of the snake heads, with the square of their tail
+var SNAKES = map[int]int{
+	16: 6,
+	47: 26,
+	49: 11,
+	56: 53,
+	62: 19,
+	64: 60,
+	87: 24,
+	93: 73,
+	95: 75,
+	98: 78,
+}
+
+// LADDERS are the squares of the ladder feet, with the square of their top
+var LADDERS = map[int]int{
+	1:  38,
+	4:  14,
+	9:  31,
+	21: 42,
+	28: 84,
+	36: 44,
+	51: 67,
+	71: 91,
+	80: 100,
+}
+
+var PLAYERS_REQUIRED_TO_START_GAME = []int{2, 4}
+
+var TICKET_AMOUNTS = []int{100, 200, 500}
+
+const (
+	RAKE_PERCENTAGE = 10 // Share of the pot kept by the house
+	WAITING_BOARDS  = 3  // Empty boards kept waiting for every player count and ticket amount
+)
+
+const (
+	TURN_TIMEOUT    = 15 * time.Second // Time a player has to roll before the server rolls for them
+	AUTO_ROLL_DELAY = 2 * time.Second  // Time before the server rolls for a disconnected player
+)
@@ -0,0 +1,231 @@
+package snakes
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"messaging/common"
+	"snakes/board"
+	"snakes/snakes_board_constants"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GAME_NAME is the game the service is registered as, and the game query parameter of its connections
+const GAME_NAME = snakes_board_constants.GAME
+
+var BoardInstances map[string]*board.Board = make(map[string]*board.Board)
+
+// boardsMu guards BoardInstances, the board management goroutine changes it while the players connect
+var boardsMu sync.RWMutex
+
+// draining is set once the server shuts down, no board is created from then on
+var draining atomic.Bool
+
+// SnakesGameService hosts the Snakes and Ladders boards
+type SnakesGameService struct{}
+
+var _ common.GameService = (*SnakesGameService)(nil)
+
+func getBoard(boardId string) (*board.Board, error) {
+	boardsMu.RLock()
+	defer boardsMu.RUnlock()
+
+	boardInstance, exists := BoardInstances[boardId]
+	if !exists {
+		return nil, common.NewSocketError(common.NOT_FOUND, fmt.Sprintf("game instance not found for room ID: %s", boardId))
+	}
+
+	return boardInstance, nil
+}
+
+func (gs *SnakesGameService) ProcessMessage(boardId string, playerId string, message common.Message, rawBytes []byte) error {
+	socketMessage := message.(common.SocketMessage)
+
+	boardInstance, err := getBoard(boardId)
+	if err != nil {
+		return err
+	}
+
+	boardInstance.Lock()
+	defer boardInstance.Unlock()
+
+	switch socketMessage.GetEventName() {
+	case snakes_board_constants.BOARD_DICEROLL:
+		return boardInstance.RollDice(playerId)
+	}
+
+	log.Printf("Invalid message %s from player %s", socketMessage.GetEventName(), playerId)
+	return common.NewSocketError(common.INVALID_MESSAGE, fmt.Sprintf("invalid event name : %s", socketMessage.GetEventName()))
+}
+
+func (gs *SnakesGameService) AddPlayer(boardId string, playerId string, name string, walletAddress string) error {
+	boardInstance, err := getBoard(boardId)
+	if err != nil {
+		return err
+	}
+
+	boardInstance.Lock()
+	defer boardInstance.Unlock()
+
+	if err := boardInstance.AddPlayer(playerId, name, walletAddress); err != nil {
+		return fmt.Errorf("error adding player to board: %w", err)
+	}
+
+	return nil
+}
+
+func (gs *SnakesGameService) HandleDisconnection(boardId string, playerId string) error {
+	boardInstance, err := getBoard(boardId)
+	if err != nil {
+		return err
+	}
+
+	boardInstance.Lock()
+	defer boardInstance.Unlock()
+
+	if err := boardInstance.HandleDisconnection(playerId); err != nil {
+		log.Printf("Error handling disconnection for player %s in board %s: %v", playerId, boardId, err)
+		return err
+	}
+
+	return nil
+}
+
+// CreateEmptyBoardInstances keeps WAITING_BOARDS empty boards waiting for every player count and ticket amount
+func (gs *SnakesGameService) CreateEmptyBoardInstances() error {
+	boardsMu.Lock()
+	defer boardsMu.Unlock()
+
+	for _, playerCount := range snakes_board_constants.PLAYERS_REQUIRED_TO_START_GAME {
+		for _, amount := range snakes_board_constants.TICKET_AMOUNTS {
+			waitingBoards := 0
+
+			for _, b := range BoardInstances {
+				b.Lock()
+				if b.GetBoardStatus() == snakes_board_constants.WAITING && len(b.GetPlayers()) == 0 &&
+					b.GetMaxPlayers() == playerCount && b.GetTicketAmount() == amount {
+					waitingBoards++
+				}
+				b.Unlock()
+			}
+
+			for ; waitingBoards < snakes_board_constants.WAITING_BOARDS; waitingBoards++ {
+				boardId := primitive.NewObjectID().Hex()
+				BoardInstances[boardId] = board.NewBoard(boardId, playerCount, amount, snakes_board_constants.RAKE_PERCENTAGE)
+			}
+		}
+	}
+
+	return nil
+}
+
+// cleanupAndCreateBoards removes the boards that are over and tops up the empty ones
+func (gs *SnakesGameService) cleanupAndCreateBoards() error {
+	if draining.Load() {
+		return nil
+	}
+
+	boardsMu.Lock()
+	for id, b := range BoardInstances {
+		b.Lock()
+		status := b.GetBoardStatus()
+		b.Unlock()
+
+		if status == snakes_board_constants.FINISHED || status == snakes_board_constants.DISCARDED {
+			delete(BoardInstances, id)
+		}
+	}
+	boardsMu.Unlock()
+
+	return gs.CreateEmptyBoardInstances()
+}
+
+func (gs *SnakesGameService) StartBoardManagement() {
+	if err := gs.cleanupAndCreateBoards(); err != nil {
+		log.Printf("Error in board management: %v", err)
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := gs.cleanupAndCreateBoards(); err != nil {
+				log.Printf("Error in board management: %v", err)
+				break
+			}
+		}
+	}()
+}
+
+// ListBoards returns the boards waiting for players or being played, as the lobby lists them. The boards closed by
+// the shutdown are listed UNAVAILABLE.
+func (gs *SnakesGameService) ListBoards() []common.BoardSummary {
+	boardsMu.RLock()
+	defer boardsMu.RUnlock()
+
+	boards := []common.BoardSummary{}
+
+	for _, b := range BoardInstances {
+		b.Lock()
+		if status := b.GetBoardStatus(); status == snakes_board_constants.WAITING || status == snakes_board_constants.PLAYING || status == snakes_board_constants.UNAVAILABLE {
+			boards = append(boards, summarize(b))
+		}
+		b.Unlock()
+	}
+
+	return boards
+}
+
+// Drain stops creating boards when the server shuts down. The WAITING boards are closed to new players and the
+// state of the boards being played is stored.
+func (gs *SnakesGameService) Drain() error {
+	draining.Store(true)
+
+	boardsMu.RLock()
+	defer boardsMu.RUnlock()
+
+	errs := []error{}
+
+	for boardId, b := range BoardInstances {
+		b.Lock()
+		err := b.Drain()
+		b.Unlock()
+
+		if err != nil {
+			log.Printf("Error draining board %s: %v", boardId, err)
+			errs = append(errs, fmt.Errorf("board %s: %w", boardId, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// summarize returns the lobby listing of the board, which must be locked
+func summarize(b *board.Board) common.BoardSummary {
+	players := []common.PlayerSummary{}
+	for _, p := range b.GetPlayers() {
+		players = append(players, common.PlayerSummary{
+			PlayerId: p.ID,
+			Name:     p.Name,
+		})
+	}
+
+	return common.BoardSummary{
+		Game:                       GAME_NAME,
+		BoardId:                    b.GetID(),
+		Players:                    players,
+		PlayersRequiredToStartGame: b.GetMaxPlayers(),
+		Status:                     string(b.GetBoardStatus()),
+		TicketAmount:               b.GetTicketAmount(),
+		Settings: map[string]interface{}{
+			"boardSize":      snakes_board_constants.BOARD_SIZE,
+			"rakePercentage": b.GetRakePercentage(),
+		},
+	}
+}
@@ -0,0 +1,197 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"messaging/common"
+	"metagame/gameserver/config"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RS405 = "Insufficient balance"
+)
+
+// PlatformWallet calls the wallet API of the platform, every game moves the players' money through it.
+// The bets and the wins are booked on the endpoints of the game, e.g. /core/crypto/game/ludoBet for Ludo.
+type PlatformWallet struct {
+	Game string // Name of the game the transactions are booked for
+}
+
+// NewPlatformWallet returns the wallet booking the transactions of the game
+func NewPlatformWallet(game string) PlatformWallet {
+	return PlatformWallet{Game: game}
+}
+
+func (w PlatformWallet) Bet(walletAddress string, amount float64) error {
+	payload := BetAndWinPayload{
+		WalletAddress: walletAddress,
+		Amount:        amount,
+	}
+
+	var response BetResponse
+	err := createTransaction(fmt.Sprintf("/core/crypto/game/%sBet", w.Game), payload, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.Code != "RS200" {
+		// log.Printf("Bet transaction failed with code: %s", response.Code)
+		switch response.Code {
+		case "RS405":
+			return common.NewSocketError(common.INSUFFICIENT_BALANCE, RS405)
+		}
+	}
+
+	return nil
+}
+
+func (w PlatformWallet) Win(walletAddress string, amount float64) error {
+	payload := BetAndWinPayload{
+		WalletAddress: walletAddress,
+		Amount:        amount,
+	}
+
+	var response WinResponse
+	err := createTransaction(fmt.Sprintf("/core/crypto/game/%sWin", w.Game), payload, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.Code != "RS200" {
+		// log.Printf("Win transaction failed with code: %s", response.Code)
+		return fmt.Errorf("win transaction failed with code: %s", response.Code)
+	}
+
+	return nil
+}
+
+func (PlatformWallet) Refund(playerId string, amount float64, gameId string) error {
+	payload := RefundPayload{
+		PlayerId:        playerId,
+		Amount:          amount,
+		TransactionUuid: uuid.New().String(),
+		RequestUuid:     uuid.New().String(),
+		Currency:        "INR",
+		GameId:          gameId,
+	}
+
+	var response RefundResponse
+	return createTransaction("/wallet/refund", payload, &response)
+}
+
+func createTransaction(endpoint string, payload interface{}, response interface{}) error {
+	cfg := config.GetConfig()
+
+	// log.Printf("Created payload: %+v", payload)
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		// log.Printf("Failed to marshal payload: %v", err)
+		return fmt.Errorf("failed to marshal payload: %v", err)
+	}
+	// log.Printf("Marshalled JSON data: %s", string(jsonData))
+
+	// log.Printf("Making POST request to %s", cfg.BasePlatformAPIUrl+endpoint)
+	resp, err := http.Post(cfg.BasePlatformAPIUrl+endpoint, "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		// log.Printf("Failed to make transaction request: %v", err)
+		return err
+	}
+
+	defer resp.Body.Close()
+	// log.Printf("Received response with status code: %d", resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		// log.Printf("Failed to read response body: %v", err)
+		return fmt.Errorf("failed to read response body: %v", err)
+	}
+	// log.Printf("Response body: %s", string(body))
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		// log.Printf("Failed to unmarshal response: %v", err)
+		return fmt.Errorf("failed to parse response JSON: %v", err)
+	}
+	// log.Printf("Parsed response: %+v", response)
+
+	if resp.StatusCode != http.StatusOK {
+		// log.Printf("Transaction failed with HTTP status: %d", resp.StatusCode)
+		return fmt.Errorf("transaction failed with status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+type BetAndWinPayload struct {
+	WalletAddress string  `json:"walletAddress"`
+	Amount        float64 `json:"amount"`
+}
+
+type RefundPayload struct {
+	PlayerId        string  `json:"playerId"`
+	Amount          float64 `json:"amount"`
+	TransactionUuid string  `json:"transactionUuid"`
+	RequestUuid     string  `json:"requestUuid"`
+	Currency        string  `json:"currency"`
+	GameId          string  `json:"gameId"`
+}
+
+type BetResponse struct {
+	User        string  `json:"user"`
+	Code        string  `json:"code"`
+	Status      string  `json:"status"`
+	RequestUuid string  `json:"requestUuid"`
+	Balance     float64 `json:"balance"`
+}
+
+type RefundResponse struct {
+	User        string  `json:"user"`
+	Status      string  `json:"status"`
+	RequestUuid string  `json:"requestUuid"`
+	Balance     float64 `json:"balance"`
+}
+
+type WinResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		PlayerResponse struct {
+			ID          string   `json:"_id"`
+			PhoneNumber string   `json:"phoneNumber"`
+			SkinID      string   `json:"skinId"`
+			Tags        []string `json:"tags"`
+			Balance     []struct {
+				// Define fields for balance object
+			} `json:"balance"`
+			BankDetails []struct {
+				// Define fields for bank details array
+			} `json:"bankDetails"`
+			Email          string  `json:"email"`
+			Name           string  `json:"name"`
+			CurrentBalance float64 `json:"currentBalance"`
+		} `json:"playerResponse"`
+		NewTransaction struct {
+			Player          string  `json:"player"`
+			Amount          float64 `json:"amount"`
+			SkinID          string  `json:"skinId"`
+			Currency        string  `json:"currency"`
+			TransactionType string  `json:"transactionType"`
+			MoneyType       string  `json:"moneyType"`
+			OpeningBalance  float64 `json:"openingBalance"`
+			ClosingBalance  float64 `json:"closingBalance"`
+			Details         []struct {
+				// Define fields for details object
+			} `json:"details"`
+			IsTransactionSuccess bool   `json:"isTransactionSuccess"`
+			ID                   string `json:"_id"`
+			CreatedAt            string `json:"createdAt"`
+			UpdatedAt            string `json:"updatedAt"`
+			Version              int    `json:"__v"`
+		} `json:"newTransaction"`
+	} `json:"data"`
+}